@@ -2,22 +2,30 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"math/rand"
 	"os"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 
 	"flowShop/internal/aco"
 	"flowShop/internal/bench"
+	"flowShop/internal/exact"
 	"flowShop/internal/flowshop"
 	"flowShop/internal/ga"
+	"flowShop/internal/heur"
+	"flowShop/internal/ils"
+	"flowShop/internal/ls"
+	"flowShop/internal/nonperm"
 	"flowShop/internal/opt"
 	"flowShop/internal/pso"
 	"flowShop/internal/sa"
 	"flowShop/internal/ts"
+	"flowShop/internal/vns"
 )
 
 type gaAdapter struct{ s *ga.Solver }
@@ -28,13 +36,40 @@ func (a gaAdapter) Solve(ctx context.Context, inst *flowshop.Instance) (opt.Resu
 
 // Фабрики
 
-func newGAFactory(cfg ga.Config) func(seed int64) opt.Optimizer {
+// newGAFactory строит GA; при trace > 0 ход поиска печатается в stderr каждые trace поколений.
+func newGAFactory(cfg ga.Config, trace int) func(seed int64) opt.Optimizer {
 	return func(seed int64) opt.Optimizer {
 		solver, _ := ga.New(cfg, rand.New(rand.NewSource(seed)))
+		if trace > 0 {
+			solver.Progress = func(p ga.Progress) {
+				if p.Generation%trace != 0 {
+					return
+				}
+				fmt.Fprintf(os.Stderr,
+					"    GA seed=%d gen=%d best=%d hamming=%.3f position=%.3f unique=%d restarts=%d\n",
+					seed, p.Generation, p.BestMakespan,
+					p.Diversity.Hamming, p.Diversity.Position, p.Diversity.Unique, p.Restarts,
+				)
+			}
+		}
 		return gaAdapter{s: solver}
 	}
 }
 
+func newGAIslandFactory(cfg ga.IslandConfig) func(seed int64) opt.Optimizer {
+	return func(seed int64) opt.Optimizer {
+		solver, _ := ga.NewIsland(cfg, rand.New(rand.NewSource(seed)))
+		return solver
+	}
+}
+
+func newNSGA2Factory(cfg ga.NSGA2Config) func(seed int64) opt.MultiOptimizer {
+	return func(seed int64) opt.MultiOptimizer {
+		solver, _ := ga.NewNSGA2(cfg, rand.New(rand.NewSource(seed)))
+		return solver
+	}
+}
+
 func newSAFactory(cfg sa.Config) func(seed int64) opt.Optimizer {
 	return func(seed int64) opt.Optimizer {
 		solver, _ := sa.New(cfg, rand.New(rand.NewSource(seed)))
@@ -42,6 +77,13 @@ func newSAFactory(cfg sa.Config) func(seed int64) opt.Optimizer {
 	}
 }
 
+func newTemperingFactory(cfg sa.TemperingConfig) func(seed int64) opt.Optimizer {
+	return func(seed int64) opt.Optimizer {
+		solver, _ := sa.NewTempering(cfg, rand.New(rand.NewSource(seed)))
+		return solver
+	}
+}
+
 func newTSFactory(cfg ts.Config) func(seed int64) opt.Optimizer {
 	return func(seed int64) opt.Optimizer {
 		solver, _ := ts.New(cfg, rand.New(rand.NewSource(seed)))
@@ -49,6 +91,20 @@ func newTSFactory(cfg ts.Config) func(seed int64) opt.Optimizer {
 	}
 }
 
+func newVNSFactory(cfg vns.Config) func(seed int64) opt.Optimizer {
+	return func(seed int64) opt.Optimizer {
+		solver, _ := vns.New(cfg, rand.New(rand.NewSource(seed)))
+		return solver
+	}
+}
+
+func newILSFactory(cfg ils.Config) func(seed int64) opt.Optimizer {
+	return func(seed int64) opt.Optimizer {
+		solver, _ := ils.New(cfg, rand.New(rand.NewSource(seed)))
+		return solver
+	}
+}
+
 func newACOFactory(cfg aco.Config) func(seed int64) opt.Optimizer {
 	return func(seed int64) opt.Optimizer {
 		solver, _ := aco.New(cfg, rand.New(rand.NewSource(seed)))
@@ -63,16 +119,48 @@ func newPSOFactory(cfg pso.Config) func(seed int64) opt.Optimizer {
 	}
 }
 
+func newExactFactory(cfg exact.Config) func(seed int64) opt.Optimizer {
+	return func(seed int64) opt.Optimizer {
+		solver, _ := exact.New(cfg)
+		return solver
+	}
+}
+
+// newHeuristicFactory оборачивает детерминированную эвристику: сид не используется.
+func newHeuristicFactory(h opt.Optimizer) func(seed int64) opt.Optimizer {
+	return func(seed int64) opt.Optimizer {
+		return h
+	}
+}
+
+// newNonPermFactory строит неперестановочный табу-поиск поверх базового перестановочного алгоритма.
+func newNonPermFactory(cfg nonperm.Config, base func(seed int64) opt.Optimizer) func(seed int64) opt.Optimizer {
+	return func(seed int64) opt.Optimizer {
+		solver, _ := nonperm.New(cfg, base(seed), rand.New(rand.NewSource(seed)))
+		return solver
+	}
+}
+
+// nonPermMakespan возвращает makespan неперестановочного расписания из Meta результата TS-NP.
+func nonPermMakespan(res opt.Result) int {
+	if ms, ok := res.Meta["sequences_makespan"].(int); ok {
+		return ms
+	}
+	return res.Makespan
+}
+
 func main() {
 	// CLI флаги для настройки параметров алгоритмов и политики запуска
 	var (
 		out          = flag.String("out", "artifacts/results.csv", "путь к выходному CSV-файлу")
 		pairs        = flag.String("pairs", "20x5,50x10,100x20", "конфигурации: количество работ Х количество станков (через запятую)")
-		algos        = flag.String("algos", "GA,SA,TS,ACO,PSO", "список алгоритмов: GA, SA, TS, ACO, PSO (через запятую)")
+		algos        = flag.String("algos", "GA,SA,TS,ACO,PSO", "список алгоритмов: GA, GA-ISLAND, SA, SA-PT, TS, ACO, PSO, VNS, ILS, TS-NP, NEH, JOHNSON, CDS, PALMER, GUPTA, RZ, LR, FRB1..FRB5, BB (через запятую)")
 		runs         = flag.Int("runs", 30, "количество запусков каждого алгоритма (с разными сидами)")
 		baseSeed     = flag.Int64("seed", 1000, "базовый сид для запусков алгоритмов")
 		instanceSeed = flag.Int64("instance_seed", 777, "базовый сид для генерации экземпляров задачи (фиксирован для конфигурации)")
 		perRunTO     = flag.Duration("per_run_timeout", 0, "таймаут одного запуска; 0 — без ограничения")
+		stageMach    = flag.String("stage_machines", "", "гибридный flow-shop: число параллельных станков по стадиям, шаблон повторяется по стадиям (например 2,1,3); пусто — классический PFSP")
+		assignRule   = flag.String("assign", "earliest-available", "правило назначения станка в гибридном flow-shop: earliest-available | earliest-completion")
 
 		// --- Генетический алгоритм ---
 		gaPop   = flag.Int("ga_pop", 150, "размер популяции")
@@ -81,14 +169,64 @@ func main() {
 		gaTour  = flag.Int("ga_tour", 5, "размер турнирной выборки")
 		gaCx    = flag.Float64("ga_cx", 0.90, "вероятность применения кроссовера")
 		gaMut   = flag.Float64("ga_mut", 0.15, "вероятность мутации")
+		gaCxOp  = flag.String("ga_cx_op", "ox", "оператор кроссовера: ox | pmx | cx | two-point | sjox | sbox | sb2ox")
+		gaMutOp = flag.String("ga_mut_op", "swap", "оператор мутации: swap | insert | inversion | scramble | multi-swap")
+		gaSwaps = flag.Int("ga_mut_swaps", 3, "число обменов для мутации multi-swap")
+		gaSel   = flag.String("ga_sel", "tournament", "схема отбора: tournament | roulette | rank | sus | truncation")
+		gaTrunc = flag.Float64("ga_trunc", 0.5, "доля лучших особей для отбора усечением")
+		gaRepl  = flag.String("ga_repl", "generational", "политика замены: generational | steady-state | mu-plus-lambda")
+		gaDedup = flag.Bool("ga_dedup", false, "заменять повторяющиеся особи случайными перестановками")
+		gaRDiv  = flag.Float64("ga_restart_div", 0, "порог разнообразия (доля отличающихся от лучшей особи позиций) для перезапуска; 0 — без перезапусков")
+		gaRKeep = flag.Int("ga_restart_keep", 4, "число лучших особей, сохраняемых при перезапуске")
+		gaTrace = flag.Int("ga_trace", 0, "печатать ход GA (лучшее значение и разнообразие) каждые N поколений; 0 — не печатать")
+
+		gaAdapt      = flag.String("ga_adapt", "fixed", "адаптация GA: fixed | srinivas-patnaik | pursuit | bandit")
+		gaAdaptCx    = flag.String("ga_adapt_cx", "ox,pmx,two-point,sjox,sbox,sb2ox", "кроссоверы для pursuit/bandit через запятую; пусто — оператор -ga_cx_op")
+		gaAdaptMut   = flag.String("ga_adapt_mut", "swap,insert,inversion,scramble", "мутации для pursuit/bandit через запятую; пусто — оператор -ga_mut_op")
+		gaAdaptRate  = flag.Float64("ga_adapt_rate", 0.3, "скорость обучения адаптивного преследования")
+		gaAdaptMinP  = flag.Float64("ga_adapt_min_prob", 0.05, "минимальная вероятность оператора при преследовании")
+		gaAdaptExplr = flag.Float64("ga_adapt_explore", 1.0, "коэффициент исследования UCB1")
+
+		// --- Островная модель GA (остальные параметры — как у GA) ---
+		islandK        = flag.Int("island_k", 4, "GA-ISLAND: число островов")
+		islandPop      = flag.Int("island_pop", 50, "GA-ISLAND: размер популяции одного острова")
+		islandInterval = flag.Int("island_interval", 20, "GA-ISLAND: число поколений между миграциями")
+		islandMigrants = flag.Int("island_migrants", 2, "GA-ISLAND: число мигрантов, отправляемых каждому соседу")
+		islandTopology = flag.String("island_topology", "ring", "GA-ISLAND: топология миграции: ring | full")
+		islandRepl     = flag.String("island_repl", "worst", "GA-ISLAND: какие особи вытесняются мигрантами: worst | random")
+
+		// --- Многокритериальный режим (NSGA-II) ---
+		moAlgos  = flag.String("mo_algos", "", "список многокритериальных алгоритмов: NSGA2 (через запятую); пусто — режим отключён")
+		moOut    = flag.String("mo_out", "artifacts/results_mo.csv", "путь к выходному CSV-файлу многокритериального режима")
+		moObjs   = flag.String("mo_objs", "makespan,flowtime", "критерии: makespan, flowtime, tardiness (через запятую)")
+		nsgaPop  = flag.Int("nsga_pop", 100, "NSGA-II: размер популяции")
+		nsgaGen  = flag.Int("nsga_gen", 300, "NSGA-II: количество поколений")
+		nsgaTour = flag.Int("nsga_tour", 2, "NSGA-II: размер турнирной выборки")
+		nsgaCx   = flag.Float64("nsga_cx", 0.90, "NSGA-II: вероятность применения кроссовера")
+		nsgaMut  = flag.Float64("nsga_mut", 0.20, "NSGA-II: вероятность мутации")
 
 		// --- Алгоритм имитации отжига ---
 		saIterPerJob = flag.Int("sa_iter_per_job", 2500, "количество итераций на одну работу (используется, если sa_iter == 0)")
 		saIter       = flag.Int("sa_iter", 0, "общее количество итераций (0 => sa_iter_per_job × nJobs)")
-		saT0         = flag.Float64("sa_t0", 2000.0, "начальная температура")
-		saTmin       = flag.Float64("sa_tmin", 0.5, "конечная температура")
+		saTempMode   = flag.String("sa_temp", "fixed", "задание температур: fixed | acceptance | osman-potts")
+		saT0         = flag.Float64("sa_t0", 2000.0, "начальная температура (sa_temp=fixed)")
+		saTmin       = flag.Float64("sa_tmin", 0.5, "конечная температура (sa_temp=fixed)")
+		saAcc0       = flag.Float64("sa_acc0", 0.8, "целевая начальная вероятность принятия ухудшения (sa_temp=acceptance)")
+		saAccF       = flag.Float64("sa_accf", 0.01, "целевая конечная вероятность принятия ухудшения (sa_temp=acceptance)")
+		saSamples    = flag.Int("sa_samples", 200, "число ухудшающих ходов для калибровки температур (sa_temp=acceptance)")
+		saOPc0       = flag.Float64("sa_op_c0", 5.0, "коэффициент c начальной температуры c·ΣP/(10·n·m) (sa_temp=osman-potts)")
+		saOPcf       = flag.Float64("sa_op_cf", 0.1, "коэффициент c конечной температуры (sa_temp=osman-potts)")
 		saAlpha      = flag.Float64("sa_alpha", 0.995, "коэффициент охлаждения (alpha)")
-		saNeigh      = flag.String("sa_neigh", "swap", "тип окрестности: swap | insert")
+		saNeigh      = flag.String("sa_neigh", "swap", "тип окрестности: swap | insert | block | reverse | mixed")
+		saCooling    = flag.String("sa_cooling", "geometric", "схема охлаждения: geometric | linear | logarithmic | lundy-mees | auto")
+		saChain      = flag.Int("sa_chain", 1, "длина цепи Маркова (итераций на одном уровне температуры)")
+		saStall      = flag.Int("sa_stall", 0, "число итераций без улучшения до повторного нагрева (0 — без нагрева)")
+		saReheat     = flag.Float64("sa_reheat", 0.5, "температура повторного нагрева в долях начальной")
+		saRestart    = flag.Bool("sa_restart_best", true, "при нагреве продолжать из лучшего найденного решения")
+
+		// --- Параллельный отжиг (остальные параметры — как у SA; температуры лестницы — от sa_tmin до sa_t0) ---
+		ptReplicas = flag.Int("pt_replicas", 8, "SA-PT: число реплик")
+		ptInterval = flag.Int("pt_interval", 50, "SA-PT: число итераций между обменами соседних реплик")
 
 		// --- Табу-поиск ---
 		tsIterPerJob = flag.Int("ts_iter_per_job", 250, "количество итераций на одну работу (используется, если ts_iter == 0)")
@@ -96,7 +234,28 @@ func main() {
 		tsTenure     = flag.Int("ts_tenure", 7, "длина табу-списка (в итерациях)")
 		tsTenureRand = flag.Int("ts_tenure_rand", 3, "случайное добавление к сроку табу [0..rand]")
 		tsNeighbors  = flag.Int("ts_neighbors", 90, "количество рассматриваемых соседей на итерацию")
-		tsNeigh      = flag.String("ts_neigh", "insert", "тип окрестности: insert | swap")
+		tsNeigh      = flag.String("ts_neigh", "insert", "тип окрестности: insert | swap | block | reverse | mixed")
+		tsSearch     = flag.String("ts_search", "sampled", "окрестность TS: sampled (случайные соседи) | critical (границы критических блоков) | full (полный просмотр insert/swap)")
+		tsAttr       = flag.String("ts_attr", "move", "атрибут табу: move | job-position | adjacency | hash (Зобрист)")
+		tsReactive   = flag.Bool("ts_reactive", false, "реактивный TS: срок табу подстраивается при повторных посещениях решений")
+		tsReactInc   = flag.Float64("ts_react_inc", 1.2, "реактивный TS: множитель увеличения срока табу при повторе")
+		tsReactDec   = flag.Float64("ts_react_dec", 0.9, "реактивный TS: множитель уменьшения срока табу без повторов")
+		tsReactIntvl = flag.Int("ts_react_interval", 100, "реактивный TS: число итераций без повторов до уменьшения срока табу")
+		tsReactMax   = flag.Int("ts_react_max", 50, "реактивный TS: максимальный срок табу")
+		tsCandidates = flag.String("ts_candidates", "all", "список кандидатов режима full: all | critical (без ходов внутри критического блока)")
+		tsWindow     = flag.Int("ts_window", 0, "максимальное расстояние между позициями хода в режиме full (0 — без ограничения; для окрестности swap обязательно)")
+		tsElite      = flag.Int("ts_elite", 0, "число элитных решений для возврата при застое (0 — без возврата)")
+		tsBackJump   = flag.Int("ts_backjump", 0, "число итераций без улучшения до возврата к элитному решению (0 — без возврата)")
+		tsFreqPen    = flag.Float64("ts_freq_penalty", 0, "коэффициент частотного штрафа пар работа—позиция при диверсификации (0 — без диверсификации)")
+		tsDivAfter   = flag.Int("ts_div_after", 0, "число итераций без улучшения до фазы диверсификации (0 — без диверсификации)")
+		tsDivLen     = flag.Int("ts_div_len", 0, "максимальная длина фазы диверсификации (в итерациях)")
+		tsArchive    = flag.Int("ts_archive", 0, "размер архива лучших различных решений (0 — без архива)")
+		tsIntensify  = flag.Int("ts_intensify_after", 0, "число итераций без улучшения до перезапуска с решения архива (0 — без интенсификации)")
+		tsRestart    = flag.Int("ts_restart_after", 0, "число итераций без улучшения до перезапуска со случайной перестановки (0 — без перезапусков)")
+
+		// --- Случайные ходы SA и TS ---
+		moveBlock   = flag.Int("move_block", 3, "SA/TS: максимальная длина переносимого блока (окрестности block и mixed)")
+		moveWeights = flag.String("move_weights", "1,1,1,1", "SA/TS: веса swap,insert,block,reverse окрестности mixed")
 
 		// --- Муравьиный алгоритм ---
 		acoIterPerJob = flag.Int("aco_iter_per_job", 120, "количество итераций на одну работу (используется, если aco_iter == 0)")
@@ -108,6 +267,59 @@ func main() {
 		acoQ          = flag.Float64("aco_q", 1000.0, "константа отложения феромонов")
 		acoTau0       = flag.Float64("aco_tau0", 1.0, "начальный уровень феромонов")
 		acoCandK      = flag.Int("aco_k", 0, "размер списка кандидатов (0 — все оставшиеся)")
+		acoVariant    = flag.String("aco_variant", "as", "вариант ACO: as | mmas (MAX–MIN) | acs (Ant Colony System)")
+		acoDeposit    = flag.String("aco_deposit", "iteration", "отложение феромона по лучшему решению: iteration (итерации) | global (за всё время)")
+		acoPBest      = flag.Float64("aco_pbest", 0.05, "MMAS: вероятность p_best для вычисления τmin")
+		acoStag       = flag.Int("aco_stagnation", 0, "число итераций без улучшения до сглаживания феромона (0 — без сглаживания)")
+		acoSmooth     = flag.Float64("aco_smoothing", 1.0, "доля сглаживания феромона (1 — переинициализация)")
+		acoQ0         = flag.Float64("aco_q0", 0.9, "ACS: вероятность жадного выбора")
+		acoXi         = flag.Float64("aco_xi", 0.1, "ACS: коэффициент локального обновления феромона")
+		acoPheromone  = flag.String("aco_pheromone", "sequence", "модель феромона: sequence (работа → следующая работа) | position (работа → позиция)")
+		acoSummation  = flag.Bool("aco_summation", false, "суммирующая оценка следов по позициям ≤ текущей (для -aco_pheromone position)")
+		acoHeuristic  = flag.String("aco_heuristic", "total-time", "эвристика: total-time (1/ΣP) | lookahead (простои станков при добавлении работы в частичную последовательность) | partial-makespan (makespan частичной последовательности с работой)")
+
+		// --- Поиск с чередующимися окрестностями ---
+		vnsIterPerJob = flag.Int("vns_iter_per_job", 60, "количество встрясок на одну работу (используется, если vns_iter == 0)")
+		vnsIter       = flag.Int("vns_iter", 0, "общее количество встрясок (0 => vns_iter_per_job × nJobs)")
+		vnsNeigh      = flag.String("vns_neigh", "insert,swap,block,reversal", "окрестности в порядке перебора: insert, swap, block, reversal (через запятую)")
+		vnsShake      = flag.Int("vns_shake", 1, "число случайных ходов при встряске")
+		vnsLSTries    = flag.Int("vns_ls_tries", 100, "число неудачных ходов подряд до остановки спуска")
+		vnsBlock      = flag.Int("vns_block", 4, "максимальная длина переносимого блока")
+
+		// --- Итерированный локальный поиск ---
+		ilsIterPerJob = flag.Int("ils_iter_per_job", 60, "количество итераций на одну работу (используется, если ils_iter == 0)")
+		ilsIter       = flag.Int("ils_iter", 0, "общее количество итераций (0 => ils_iter_per_job × nJobs)")
+		ilsStrength   = flag.Int("ils_strength", 3, "сила возмущения (число случайных insert-ходов)")
+		ilsAccept     = flag.String("ils_accept", "better", "критерий принятия: better | random-walk | restart")
+		ilsRestart    = flag.Int("ils_restart", 200, "число итераций без улучшения до перезапуска (для restart)")
+		ilsLSTries    = flag.Int("ils_ls_tries", 100, "число неудачных ходов подряд до остановки спуска")
+
+		// --- Неперестановочный табу-поиск (старт с решения TS) ---
+		npIterPerJob = flag.Int("np_iter_per_job", 20, "количество итераций на одну работу (используется, если np_iter == 0)")
+		npIter       = flag.Int("np_iter", 0, "общее количество итераций (0 => np_iter_per_job × nJobs)")
+		npTenure     = flag.Int("np_tenure", 7, "длина табу-списка (в итерациях)")
+		npTenureRand = flag.Int("np_tenure_rand", 3, "случайное добавление к сроку табу [0..rand]")
+		npNeighbors  = flag.Int("np_neighbors", 60, "количество рассматриваемых обменов на итерацию")
+
+		// --- Локальный поиск (гибридизация GA, ACO, PSO) ---
+		gaLS       = flag.String("ga_ls", "", "GA: окрестность спуска меметического варианта: insert | swap | or-opt | rz; пусто — без локального поиска")
+		gaLSScope  = flag.String("ga_ls_scope", "all", "GA: к каким особям применяется спуск: all | fraction | elite")
+		gaLSRate   = flag.Float64("ga_ls_rate", 0.1, "GA: вероятность спуска для потомка (для ga_ls_scope=fraction)")
+		gaLSMode   = flag.String("ga_ls_mode", "lamarckian", "GA: запись результата спуска: lamarckian | baldwinian")
+		acoLS      = flag.String("aco_ls", "", "ACO: окрестность спуска для лучшего муравья итерации; пусто — без локального поиска")
+		psoLS      = flag.String("pso_ls", "", "PSO: окрестность спуска для лучшей частицы итерации; пусто — без локального поиска")
+		lsStrategy = flag.String("ls_strategy", "first", "стратегия спуска: first | best")
+		lsPasses   = flag.Int("ls_passes", 0, "ограничение числа проходов спуска (0 — до локального оптимума)")
+		lsOrOpt    = flag.Int("ls_oropt", 3, "максимальная длина блока для окрестности or-opt")
+
+		// --- Конструктивные эвристики ---
+		lrX       = flag.Int("lr_x", 0, "LR(x): число стартовых работ (0 — max(1, n/m))")
+		frbWindow = flag.Int("frb_window", 2, "FRB4/FRB5: полуширина окна переустановки вокруг вставленной работы")
+
+		// --- Метод ветвей и границ ---
+		bbNodes = flag.Int("bb_nodes", 0, "ограничение числа узлов дерева (0 — без ограничения)")
+		bbTime  = flag.Duration("bb_time", 0, "ограничение времени поиска (0 — без ограничения)")
+		bbBound = flag.String("bb_bound", "machine-job", "нижняя оценка: machine | machine-job")
 
 		// --- Рой частиц ---
 		psoIterPerJob = flag.Int("pso_iter_per_job", 180, "количество итераций на одну работу (используется, если pso_iter == 0)")
@@ -129,6 +341,20 @@ func main() {
 		fmt.Fprintln(os.Stderr, "Конфликт:", err)
 		os.Exit(2)
 	}
+	if err := applyStageMachines(cases, *stageMach, flowshop.AssignmentRule(*assignRule)); err != nil {
+		fmt.Fprintln(os.Stderr, "Конфликт:", err)
+		os.Exit(2)
+	}
+
+	// Конфигурация локального спуска для гибридных вариантов
+	lsConfig := func(nb string) ls.Config {
+		return ls.Config{
+			Neighborhood: ls.Neighborhood(nb),
+			Strategy:     ls.Strategy(*lsStrategy),
+			MaxPasses:    *lsPasses,
+			OrOptMaxLen:  *lsOrOpt,
+		}
+	}
 
 	gaCfg := ga.Config{
 		Population:     *gaPop,
@@ -137,38 +363,217 @@ func main() {
 		TournamentSize: *gaTour,
 		CrossoverRate:  *gaCx,
 		MutationRate:   *gaMut,
+		Crossover:      ga.Crossover(*gaCxOp),
+		Mutation:       ga.Mutation(*gaMutOp),
+		MutationSwaps:  *gaSwaps,
+		Selection:      ga.Selection(*gaSel),
+		TruncationRate: *gaTrunc,
+		Replacement:    ga.Replacement(*gaRepl),
+
+		EliminateDuplicates: *gaDedup,
+		RestartDiversity:    *gaRDiv,
+		RestartKeep:         *gaRKeep,
+		LocalSearch:         lsConfig(*gaLS),
+
+		LocalSearchScope: ga.LocalSearchScope(*gaLSScope),
+		LocalSearchRate:  *gaLSRate,
+		LocalSearchMode:  ga.LocalSearchMode(*gaLSMode),
+
+		Adaptation:       ga.Adaptation(*gaAdapt),
+		AdaptRate:        *gaAdaptRate,
+		AdaptMinProb:     *gaAdaptMinP,
+		AdaptExploration: *gaAdaptExplr,
+	}
+	for _, op := range splitCSV(*gaAdaptCx) {
+		gaCfg.AdaptiveCrossovers = append(gaCfg.AdaptiveCrossovers, ga.Crossover(op))
+	}
+	for _, op := range splitCSV(*gaAdaptMut) {
+		gaCfg.AdaptiveMutations = append(gaCfg.AdaptiveMutations, ga.Mutation(op))
 	}
 	if err := gaCfg.Validate(); err != nil {
 		fmt.Fprintln(os.Stderr, "Конфликт в конфигурации генетического алгоритма:", err)
 		os.Exit(2)
 	}
 
+	islandGA := gaCfg
+	islandGA.Population = *islandPop
+	islandCfg := ga.IslandConfig{
+		Islands:           *islandK,
+		Island:            islandGA,
+		MigrationInterval: *islandInterval,
+		Migrants:          *islandMigrants,
+		Topology:          ga.Topology(*islandTopology),
+		Replacement:       ga.MigrantReplacement(*islandRepl),
+	}
+	if slices.Contains(splitCSV(*algos), "GA-ISLAND") {
+		if err := islandCfg.Validate(); err != nil {
+			fmt.Fprintln(os.Stderr, "Конфликт в конфигурации островной модели GA:", err)
+			os.Exit(2)
+		}
+	}
+
+	var objectives []flowshop.Objective
+	for _, o := range splitCSV(*moObjs) {
+		objectives = append(objectives, flowshop.Objective(o))
+	}
+	nsgaCfg := ga.NSGA2Config{
+		Population:     *nsgaPop,
+		Generations:    *nsgaGen,
+		TournamentSize: *nsgaTour,
+		CrossoverRate:  *nsgaCx,
+		MutationRate:   *nsgaMut,
+		Objectives:     objectives,
+	}
+	if *moAlgos != "" {
+		if err := nsgaCfg.Validate(); err != nil {
+			fmt.Fprintln(os.Stderr, "Конфликт в конфигурации NSGA-II:", err)
+			os.Exit(2)
+		}
+	}
+
+	weights, err := parseMoveWeights(*moveWeights)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Некорректные веса ходов:", err)
+		os.Exit(2)
+	}
+
 	saCfg := sa.Config{
-		Iterations:       *saIter,
-		IterationsPerJob: *saIterPerJob,
-		InitialTemp:      *saT0,
-		FinalTemp:        *saTmin,
-		Alpha:            *saAlpha,
-		Neighborhood:     sa.Neighborhood(*saNeigh),
+		Iterations:         *saIter,
+		IterationsPerJob:   *saIterPerJob,
+		TempMode:           sa.TempMode(*saTempMode),
+		InitialTemp:        *saT0,
+		FinalTemp:          *saTmin,
+		InitialAcceptance:  *saAcc0,
+		FinalAcceptance:    *saAccF,
+		CalibrationSamples: *saSamples,
+		InitialTempFactor:  *saOPc0,
+		FinalTempFactor:    *saOPcf,
+		Alpha:              *saAlpha,
+		Cooling:            sa.Cooling(*saCooling),
+		ChainLength:        *saChain,
+		StallIterations:    *saStall,
+		ReheatRatio:        *saReheat,
+		RestartFromBest:    *saRestart,
+		Neighborhood:       sa.Neighborhood(*saNeigh),
+		BlockMaxLen:        *moveBlock,
+		MoveWeights:        weights,
 	}
 	if err := saCfg.Validate(); err != nil {
 		fmt.Fprintln(os.Stderr, "Конфликт в конфигурации алгоритма имитации отжига:", err)
 		os.Exit(2)
 	}
 
+	ptCfg := sa.TemperingConfig{
+		Replicas:     *ptReplicas,
+		SA:           saCfg,
+		SwapInterval: *ptInterval,
+	}
+	if slices.Contains(splitCSV(*algos), "SA-PT") {
+		if err := ptCfg.Validate(); err != nil {
+			fmt.Fprintln(os.Stderr, "Конфликт в конфигурации параллельного отжига:", err)
+			os.Exit(2)
+		}
+	}
+
 	tsCfg := ts.Config{
-		Iterations:       *tsIter,
-		IterationsPerJob: *tsIterPerJob,
-		TabuTenure:       *tsTenure,
-		TabuTenureRand:   *tsTenureRand,
-		NeighborsPerIter: *tsNeighbors,
-		Neighborhood:     ts.Neighborhood(*tsNeigh),
+		Iterations:        *tsIter,
+		IterationsPerJob:  *tsIterPerJob,
+		TabuTenure:        *tsTenure,
+		TabuTenureRand:    *tsTenureRand,
+		NeighborsPerIter:  *tsNeighbors,
+		Attribute:         ts.TabuAttribute(*tsAttr),
+		Reactive:          *tsReactive,
+		ReactiveIncrease:  *tsReactInc,
+		ReactiveDecrease:  *tsReactDec,
+		ReactiveInterval:  *tsReactIntvl,
+		ReactiveMaxTenure: *tsReactMax,
+		Neighborhood:      ts.Neighborhood(*tsNeigh),
+		BlockMaxLen:       *moveBlock,
+		MoveWeights:       weights,
+		Search:            ts.SearchMode(*tsSearch),
+		Candidates:        ts.CandidateList(*tsCandidates),
+		Window:            *tsWindow,
+		EliteSize:         *tsElite,
+		BackJumpAfter:     *tsBackJump,
+		FrequencyPenalty:  *tsFreqPen,
+		DiversifyAfter:    *tsDivAfter,
+		DiversifyLength:   *tsDivLen,
+		ArchiveSize:       *tsArchive,
+		IntensifyAfter:    *tsIntensify,
+		RestartAfter:      *tsRestart,
 	}
 	if err := tsCfg.Validate(); err != nil {
 		fmt.Fprintln(os.Stderr, "Конфликт в конфигурации табушифтинга:", err)
 		os.Exit(2)
 	}
 
+	var vnsNeighborhoods []vns.Neighborhood
+	for _, nb := range splitCSV(*vnsNeigh) {
+		vnsNeighborhoods = append(vnsNeighborhoods, vns.Neighborhood(nb))
+	}
+	vnsCfg := vns.Config{
+		Iterations:       *vnsIter,
+		IterationsPerJob: *vnsIterPerJob,
+		Neighborhoods:    vnsNeighborhoods,
+		ShakeMoves:       *vnsShake,
+		LocalSearchTries: *vnsLSTries,
+		BlockMaxLen:      *vnsBlock,
+	}
+	if err := vnsCfg.Validate(); err != nil {
+		fmt.Fprintln(os.Stderr, "Конфликт в конфигурации VNS:", err)
+		os.Exit(2)
+	}
+
+	ilsCfg := ils.Config{
+		Iterations:           *ilsIter,
+		IterationsPerJob:     *ilsIterPerJob,
+		PerturbationStrength: *ilsStrength,
+		Acceptance:           ils.Acceptance(*ilsAccept),
+		RestartAfter:         *ilsRestart,
+		LocalSearchTries:     *ilsLSTries,
+	}
+	if err := ilsCfg.Validate(); err != nil {
+		fmt.Fprintln(os.Stderr, "Конфликт в конфигурации ILS:", err)
+		os.Exit(2)
+	}
+
+	npCfg := nonperm.Config{
+		Iterations:       *npIter,
+		IterationsPerJob: *npIterPerJob,
+		TabuTenure:       *npTenure,
+		TabuTenureRand:   *npTenureRand,
+		NeighborsPerIter: *npNeighbors,
+	}
+	if err := npCfg.Validate(); err != nil {
+		fmt.Fprintln(os.Stderr, "Конфликт в конфигурации неперестановочного табу-поиска:", err)
+		os.Exit(2)
+	}
+
+	bbCfg := exact.Config{
+		NodeLimit: *bbNodes,
+		TimeLimit: *bbTime,
+		Bound:     exact.Bound(*bbBound),
+	}
+	if err := bbCfg.Validate(); err != nil {
+		fmt.Fprintln(os.Stderr, "Конфликт в конфигурации метода ветвей и границ:", err)
+		os.Exit(2)
+	}
+
+	lr, err := heur.NewLR(*lrX)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Конфликт в конфигурации LR(x):", err)
+		os.Exit(2)
+	}
+	frb := make([]opt.Optimizer, 5)
+	for v := 1; v <= 5; v++ {
+		h, err := heur.NewFRB(v, *frbWindow)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Конфликт в конфигурации FRB:", err)
+			os.Exit(2)
+		}
+		frb[v-1] = h
+	}
+
 	acoCfg := aco.Config{
 		Iterations:       *acoIter,
 		IterationsPerJob: *acoIterPerJob,
@@ -179,6 +584,17 @@ func main() {
 		Q:                *acoQ,
 		Tau0:             *acoTau0,
 		CandidateK:       *acoCandK,
+		Variant:          aco.Variant(*acoVariant),
+		Deposit:          aco.DepositRule(*acoDeposit),
+		PBest:            *acoPBest,
+		StagnationIters:  *acoStag,
+		Smoothing:        *acoSmooth,
+		Q0:               *acoQ0,
+		Xi:               *acoXi,
+		Pheromone:        aco.PheromoneModel(*acoPheromone),
+		Summation:        *acoSummation,
+		Heuristic:        aco.Heuristic(*acoHeuristic),
+		LocalSearch:      lsConfig(*acoLS),
 	}
 	if err := acoCfg.Validate(); err != nil {
 		fmt.Fprintln(os.Stderr, "Конфликт в конфигурации муравьиного алгоритма:", err)
@@ -195,6 +611,7 @@ func main() {
 		VMax:             *psoVMax,
 		PosMin:           *psoPosMin,
 		PosMax:           *psoPosMax,
+		LocalSearch:      lsConfig(*psoLS),
 	}
 	if err := psoCfg.Validate(); err != nil {
 		fmt.Fprintln(os.Stderr, "Конфликт в конфигурации роя частиц:", err)
@@ -202,11 +619,30 @@ func main() {
 	}
 
 	available := map[string]bench.Algorithm{
-		"GA":  {Name: "GA", Factory: newGAFactory(gaCfg)},
+		"GA":  {Name: "GA", Factory: newGAFactory(gaCfg, *gaTrace)},
 		"SA":  {Name: "SA", Factory: newSAFactory(saCfg)},
 		"TS":  {Name: "TS", Factory: newTSFactory(tsCfg)},
 		"ACO": {Name: "ACO", Factory: newACOFactory(acoCfg)},
 		"PSO": {Name: "PSO", Factory: newPSOFactory(psoCfg)},
+		"VNS": {Name: "VNS", Factory: newVNSFactory(vnsCfg)},
+		"ILS": {Name: "ILS", Factory: newILSFactory(ilsCfg)},
+
+		"GA-ISLAND": {Name: "GA-ISLAND", Factory: newGAIslandFactory(islandCfg)},
+		"SA-PT":     {Name: "SA-PT", Factory: newTemperingFactory(ptCfg)},
+		"TS-NP":     {Name: "TS-NP", Factory: newNonPermFactory(npCfg, newTSFactory(tsCfg)), Makespan: nonPermMakespan},
+		"NEH":       {Name: "NEH", Factory: newHeuristicFactory(heur.NewNEH()), Deterministic: true},
+		"JOHNSON":   {Name: "JOHNSON", Factory: newHeuristicFactory(heur.NewJohnson()), Deterministic: true},
+		"CDS":       {Name: "CDS", Factory: newHeuristicFactory(heur.NewCDS()), Deterministic: true},
+		"PALMER":    {Name: "PALMER", Factory: newHeuristicFactory(heur.NewPalmer()), Deterministic: true},
+		"GUPTA":     {Name: "GUPTA", Factory: newHeuristicFactory(heur.NewGupta()), Deterministic: true},
+		"RZ":        {Name: "RZ", Factory: newHeuristicFactory(heur.NewRZ()), Deterministic: true},
+		"LR":        {Name: "LR", Factory: newHeuristicFactory(lr), Deterministic: true},
+		"FRB1":      {Name: "FRB1", Factory: newHeuristicFactory(frb[0]), Deterministic: true},
+		"FRB2":      {Name: "FRB2", Factory: newHeuristicFactory(frb[1]), Deterministic: true},
+		"FRB3":      {Name: "FRB3", Factory: newHeuristicFactory(frb[2]), Deterministic: true},
+		"FRB4":      {Name: "FRB4", Factory: newHeuristicFactory(frb[3]), Deterministic: true},
+		"FRB5":      {Name: "FRB5", Factory: newHeuristicFactory(frb[4]), Deterministic: true},
+		"BB":        {Name: "BB", Factory: newExactFactory(bbCfg), Deterministic: *bbTime == 0},
 	}
 
 	var selected []bench.Algorithm
@@ -219,6 +655,20 @@ func main() {
 		selected = append(selected, al)
 	}
 
+	availableMO := map[string]bench.MOAlgorithm{
+		"NSGA2": {Name: "NSGA2", Factory: newNSGA2Factory(nsgaCfg)},
+	}
+
+	var selectedMO []bench.MOAlgorithm
+	for _, a := range splitCSV(*moAlgos) {
+		al, ok := availableMO[a]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Многокритериальный алгоритм не предоставлен в программе %q; доступные: NSGA2\n", a)
+			os.Exit(2)
+		}
+		selectedMO = append(selectedMO, al)
+	}
+
 	runner := bench.Runner{
 		Runs:          *runs,
 		BaseSeed:      *baseSeed,
@@ -228,17 +678,26 @@ func main() {
 	var records []bench.Record
 	for _, c := range cases {
 		for _, a := range selected {
-			fmt.Printf("Запущен алгоритм %s; %d работ %d машин (общее кол-во запусков=%d)...\n", a.Name, c.Jobs, c.Machines, runner.Runs)
+			runs := runner.Runs
+			if a.Deterministic {
+				runs = 1
+			}
+			fmt.Printf("Запущен алгоритм %s; %d работ %d машин (общее кол-во запусков=%d)...\n", a.Name, c.Jobs, c.Machines, runs)
 
 			rec, err := runner.RunCase(ctx, c, a)
+			if errors.Is(err, opt.ErrUnsupported) {
+				fmt.Println("  Пропущен:", err)
+				continue
+			}
 			if err != nil {
 				fmt.Fprintln(os.Stderr, "Ошибка:", err)
 				os.Exit(1)
 			}
 			records = append(records, rec)
 
-			fmt.Printf("  Значение целевой функции: лучшее=%d среднее=%.2f стандартное отклонение=%.2f | Время: среднее=%.2fms среднее отклонение=%.2fms\n",
+			fmt.Printf("  Значение целевой функции: лучшее=%d среднее=%.2f стандартное отклонение=%.2f | Нижняя оценка=%d разрыв: лучший=%.2f%% средний=%.2f%% | Время: среднее=%.2fms среднее отклонение=%.2fms\n",
 				rec.MakespanBest, rec.MakespanMean, rec.MakespanStd,
+				rec.LowerBound, rec.GapLBBestPct, rec.GapLBMeanPct,
 				rec.TimeMeanMs, rec.TimeStdMs,
 			)
 		}
@@ -249,6 +708,34 @@ func main() {
 		os.Exit(1)
 	}
 	fmt.Println("Saved:", *out)
+
+	if len(selectedMO) == 0 {
+		return
+	}
+
+	var moRecords []bench.MORecord
+	for _, c := range cases {
+		fmt.Printf("Многокритериальный режим %v; %d работ %d машин (общее кол-во запусков=%d)...\n", objectives, c.Jobs, c.Machines, runner.Runs)
+
+		recs, err := runner.RunMOCase(ctx, c, selectedMO)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Ошибка:", err)
+			os.Exit(1)
+		}
+		moRecords = append(moRecords, recs...)
+
+		for _, rec := range recs {
+			fmt.Printf("  %s: HV лучшее=%.4f среднее=%.4f | IGD среднее=%.4f | eps среднее=%.4f | размер фронта=%.1f | Время: среднее=%.2fms\n",
+				rec.Algo, rec.HVBest, rec.HVMean, rec.IGDMean, rec.EpsMean, rec.FrontSizeMean, rec.TimeMeanMs,
+			)
+		}
+	}
+
+	if err := bench.WriteMOCSV(*moOut, moRecords); err != nil {
+		fmt.Fprintln(os.Stderr, "Ошибка при записи в CSV:", err)
+		os.Exit(1)
+	}
+	fmt.Println("Saved:", *moOut)
 }
 
 // helpers
@@ -286,6 +773,35 @@ func parsePairs(s string, baseInstanceSeed int64) ([]bench.Case, error) {
 	return cases, nil
 }
 
+// applyStageMachines задаёт для всех конфигураций число параллельных станков по стадиям.
+// Шаблон повторяется циклически, пока не покроет все стадии.
+func applyStageMachines(cases []bench.Case, pattern string, rule flowshop.AssignmentRule) error {
+	parts := splitCSV(pattern)
+	if len(parts) == 0 {
+		return nil
+	}
+	ks := make([]int, len(parts))
+	for i, p := range parts {
+		k, err := atoiStrict(p)
+		if err != nil {
+			return fmt.Errorf("stage_machines %q: %w", pattern, err)
+		}
+		if k <= 0 {
+			return fmt.Errorf("stage_machines %q: число станков на стадии должно быть > 0", pattern)
+		}
+		ks[i] = k
+	}
+	for i := range cases {
+		stages := make([]int, cases[i].Machines)
+		for s := range stages {
+			stages[s] = ks[s%len(ks)]
+		}
+		cases[i].StageMachines = stages
+		cases[i].Assignment = rule
+	}
+	return nil
+}
+
 func splitCSV(s string) []string {
 	var out []string
 	for _, p := range strings.Split(s, ",") {
@@ -297,6 +813,23 @@ func splitCSV(s string) []string {
 	return out
 }
 
+// parseMoveWeights разбирает веса "swap,insert,block,reverse".
+func parseMoveWeights(s string) (ls.MoveWeights, error) {
+	parts := splitCSV(s)
+	if len(parts) != 4 {
+		return ls.MoveWeights{}, fmt.Errorf("ожидается 4 веса через запятую, получено %q", s)
+	}
+	var v [4]float64
+	for i, p := range parts {
+		f, err := strconv.ParseFloat(p, 64)
+		if err != nil {
+			return ls.MoveWeights{}, err
+		}
+		v[i] = f
+	}
+	return ls.MoveWeights{Swap: v[0], Insert: v[1], Block: v[2], Reverse: v[3]}, nil
+}
+
 func atoiStrict(s string) (int, error) {
 	s = strings.TrimSpace(s)
 	v, err := strconv.Atoi(s)
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"flowShop/internal/flowshop"
+	"flowShop/internal/ls"
 	"flowShop/internal/opt"
 )
 
@@ -50,6 +51,16 @@ func (s *Solver) Solve(ctx context.Context, inst *flowshop.Instance) (opt.Result
 		return opt.Result{}, err
 	}
 
+	// Локальный спуск для гибридизации (nil — отключён)
+	var searcher *ls.Searcher
+	if s.Cfg.LocalSearch.Enabled() {
+		searcher, err = ls.New(s.Cfg.LocalSearch, inst)
+		if err != nil {
+			return opt.Result{}, err
+		}
+	}
+	lsEvals := 0
+
 	n := inst.Jobs
 
 	maxIter := s.Cfg.Iterations
@@ -73,15 +84,9 @@ func (s *Solver) Solve(ctx context.Context, inst *flowshop.Instance) (opt.Result
 	}
 
 	// Матрица феромонов
-	tau := make([]float64, (n+1)*n)
-	for i := range tau {
-		tau[i] = s.Cfg.Tau0
-	}
+	col := newColony(s.Cfg, inst, eta, s.Rng)
 
-	// Вспомогательные буферы
-	perm := make([]int, n)        // текущая перестановка
-	available := make([]int, n)   // доступные работы
-	weights := make([]float64, n) // веса вероятностного выбора
+	perm := make([]int, n) // текущая перестановка
 
 	bestPerm := make([]int, n)
 	bestCost := math.MaxInt
@@ -92,6 +97,9 @@ func (s *Solver) Solve(ctx context.Context, inst *flowshop.Instance) (opt.Result
 	rho := s.Cfg.Rho
 	Q := s.Cfg.Q
 
+	// Стагнация: итерации без улучшения лучшего решения
+	lastImprove, lastSmooth, smoothings := 0, 0, 0
+
 	for iter := 0; iter < maxIter; iter++ {
 		// Для поддержки отмены через context
 		if err := ctx.Err(); err != nil {
@@ -102,7 +110,8 @@ func (s *Solver) Solve(ctx context.Context, inst *flowshop.Instance) (opt.Result
 				Iterations:  iter,
 				Duration:    time.Since(startTime),
 				Meta: map[string]any{
-					"stopped": "context",
+					"stopped":    "context",
+					"smoothings": smoothings,
 				},
 			}, err
 		}
@@ -113,13 +122,7 @@ func (s *Solver) Solve(ctx context.Context, inst *flowshop.Instance) (opt.Result
 
 		// Муравьи пошли
 		for a := 0; a < ants; a++ {
-			constructPermutation(
-				n, tau, eta,
-				alpha, beta,
-				s.Cfg.CandidateK,
-				s.Rng,
-				perm, available, weights,
-			)
+			col.construct(perm)
 
 			cost := eval.MustMakespan(perm)
 			evals++
@@ -133,38 +136,75 @@ func (s *Solver) Solve(ctx context.Context, inst *flowshop.Instance) (opt.Result
 			if cost < bestCost {
 				bestCost = cost
 				copy(bestPerm, perm)
+				lastImprove = iter
 			}
 		}
 
-		// Испарение феромона
-		ev := 1.0 - rho
-		for i := range tau {
-			tau[i] *= ev
-			if tau[i] < 1e-12 {
-				tau[i] = 1e-12
+		// Локальный спуск от лучшего муравья итерации
+		if searcher != nil {
+			var k int
+			iterBestCost, k = searcher.Improve(ctx, iterBestPerm, iterBestCost)
+			evals += k
+			lsEvals += k
+			if iterBestCost < bestCost {
+				bestCost = iterBestCost
+				copy(bestPerm, iterBestPerm)
+				lastImprove = iter
 			}
 		}
 
-		// Добавление феромона только по лучшему пути итерации
-		dep := Q / float64(iterBestCost)
-		addPheromonePath(tau, n, iterBestPerm, dep)
+		// Глобальное обновление феромона по лучшему решению итерации или за всё время
+		if s.Cfg.Deposit == DepositGlobal {
+			col.update(bestPerm, bestCost, bestCost)
+		} else {
+			col.update(iterBestPerm, iterBestCost, bestCost)
+		}
+
+		// Сглаживание (переинициализация) феромона при стагнации
+		if s.Cfg.StagnationIters > 0 && iter-max(lastImprove, lastSmooth) >= s.Cfg.StagnationIters {
+			col.smooth()
+			lastSmooth = iter
+			smoothings++
+		}
 	}
 
+	meta := map[string]any{
+		"ants":        ants,
+		"alpha":       alpha,
+		"beta":        beta,
+		"rho":         rho,
+		"Q":           Q,
+		"tau0":        s.Cfg.Tau0,
+		"candidate_k": s.Cfg.CandidateK,
+		"variant":     string(s.Cfg.Variant),
+		"deposit":     string(s.Cfg.Deposit),
+		"pheromone":   string(s.Cfg.Pheromone),
+		"summation":   s.Cfg.Summation,
+		"heuristic":   string(s.Cfg.Heuristic),
+		"smoothings":  smoothings,
+	}
+	switch s.Cfg.Variant {
+	case VariantMMAS:
+		meta["p_best"] = s.Cfg.PBest
+		meta["tau_min"] = col.tauMin
+		meta["tau_max"] = col.tauMax
+	case VariantACS:
+		meta["q0"] = s.Cfg.Q0
+		meta["xi"] = s.Cfg.Xi
+		meta["acs_tau0"] = col.tau0
+	}
+	if searcher != nil {
+		meta["local_search"] = string(s.Cfg.LocalSearch.Neighborhood)
+		meta["ls_strategy"] = string(s.Cfg.LocalSearch.Strategy)
+		meta["ls_evaluations"] = lsEvals
+	}
 	return opt.Result{
 		Permutation: bestPerm,
 		Makespan:    bestCost,
 		Evaluations: evals,
 		Iterations:  maxIter,
 		Duration:    time.Since(startTime),
-		Meta: map[string]any{
-			"ants":        ants,
-			"alpha":       alpha,
-			"beta":        beta,
-			"rho":         rho,
-			"Q":           Q,
-			"tau0":        s.Cfg.Tau0,
-			"candidate_k": s.Cfg.CandidateK,
-		},
+		Meta:        meta,
 	}, nil
 }
 
@@ -172,94 +212,6 @@ func tauIdx(n, from, to int) int {
 	return from*n + to
 }
 
-// addPheromonePath усиливает феромон вдоль полного пути перестановки.
-// от фиктивного старта до последней работы.
-func addPheromonePath(tau []float64, n int, perm []int, delta float64) {
-	if len(perm) == 0 {
-		return
-	}
-	start := n
-	first := perm[0]
-	tau[tauIdx(n, start, first)] += delta
-	for i := 0; i < len(perm)-1; i++ {
-		from := perm[i]
-		to := perm[i+1]
-		tau[tauIdx(n, from, to)] += delta
-	}
-}
-
-// constructPermutation строит одну перестановку работ.
-// На каждом шаге выбирается следующая работа вероятностно по формуле ACO.
-func constructPermutation(
-	n int,
-	tau []float64,
-	eta []float64,
-	alpha float64,
-	beta float64,
-	candidateK int,
-	rng *rand.Rand,
-	outPerm []int,
-	available []int,
-	weights []float64,
-) {
-	for i := 0; i < n; i++ {
-		available[i] = i
-	}
-	rem := n
-
-	prev := n // prev — предыдущая вершина
-
-	for pos := 0; pos < n; pos++ {
-		// Ограничение списка кандидатов
-		k := rem
-		if candidateK > 0 && candidateK < rem {
-			k = candidateK
-			for t := 0; t < k; t++ {
-				r := t + rng.Intn(rem-t)
-				available[t], available[r] = available[r], available[t]
-			}
-		}
-
-		// Подсчёт весов вероятностей выбора
-		sumW := 0.0
-		for i := 0; i < k; i++ {
-			j := available[i]
-			t := tau[tauIdx(n, prev, j)]
-
-			// Формула ACO
-			w := fastPow(t, alpha) * fastPow(eta[j], beta)
-			weights[i] = w
-			sumW += w
-		}
-
-		// Стохастический выбор следующей работы
-		var chosenIdx int
-		if sumW <= 0 {
-			chosenIdx = rng.Intn(k)
-		} else {
-			r := rng.Float64() * sumW
-			acc := 0.0
-			chosenIdx = k - 1
-			for i := 0; i < k; i++ {
-				acc += weights[i]
-				if r <= acc {
-					chosenIdx = i
-					break
-				}
-			}
-		}
-
-		job := available[chosenIdx]
-		outPerm[pos] = job
-		prev = job
-
-		// Удаляем выбранную работу из списка доступных
-		available[chosenIdx], available[rem-1] =
-			available[rem-1], available[chosenIdx]
-		rem--
-	}
-}
-
 // fastPow — оптимизация для частых степеней.
 // Таким образом избегаем вызова math.Pow в простых случаях.
 func fastPow(x, p float64) float64 {
@@ -0,0 +1,313 @@
+package aco
+
+import (
+	"math"
+	"math/rand"
+
+	"flowShop/internal/flowshop"
+)
+
+// tauFloor — нижняя граница феромона AS и ACS, исключающая нулевые веса выбора.
+const tauFloor = 1e-12
+
+// colony — феромонная модель и построение решений муравьями.
+//
+// В модели sequence tau[from·n+to] — феромон перехода от работы from к работе to
+// (строка n — фиктивный старт), в модели position tau[pos·n+job] — феромон
+// постановки работы job в позицию pos.
+type colony struct {
+	cfg  Config
+	inst *flowshop.Instance
+	n    int
+	rng  *rand.Rand
+
+	tau []float64
+	eta []float64
+
+	// tau0 — начальный уровень следов; в ACS после первой итерации
+	// заменяется на Q / (n·C_best) (Dorigo, Gambardella, 1997)
+	tau0 float64
+
+	// Границы феромона MMAS; до первого обновления не определены
+	tauMin, tauMax float64
+
+	// initialized — следы MMAS и ACS переинициализированы по первому решению
+	initialized bool
+
+	// Вспомогательные буферы
+	available []int     // доступные работы
+	weights   []float64 // веса вероятностного выбора
+	sums      []float64 // суммы следов по позициям ≤ текущей (суммирующая оценка)
+	front     []int     // завершение частичной последовательности на станках (lookahead, partial-makespan)
+}
+
+func newColony(cfg Config, inst *flowshop.Instance, eta []float64, rng *rand.Rand) *colony {
+	n := inst.Jobs
+	c := &colony{
+		cfg:       cfg,
+		inst:      inst,
+		n:         n,
+		rng:       rng,
+		tau:       make([]float64, (n+1)*n),
+		eta:       eta,
+		tau0:      cfg.Tau0,
+		available: make([]int, n),
+		weights:   make([]float64, n),
+		sums:      make([]float64, n),
+		front:     make([]int, inst.Machines),
+	}
+	for i := range c.tau {
+		c.tau[i] = c.tau0
+	}
+	return c
+}
+
+// construct строит одну перестановку работ.
+// На каждом шаге следующая работа выбирается вероятностно по формуле ACO;
+// в ACS с вероятностью Q0 выбирается работа с наибольшим весом
+// (псевдослучайное пропорциональное правило), а феромон выбранного перехода
+// сразу ослабевает (локальное обновление).
+//
+// При суммирующей оценке (Merkle, Middendorf, 2000) след работы в позиции pos —
+// сумма следов по позициям 0..pos: работа, которой «пора» стоять раньше, не теряет
+// шансов, если её позиция уже занята.
+func (c *colony) construct(outPerm []int) {
+	n := c.n
+	available := c.available
+	weights := c.weights
+	rng := c.rng
+	acs := c.cfg.Variant == VariantACS
+	position := c.cfg.Pheromone == PheromonePosition
+	lookahead := c.cfg.Heuristic == HeuristicLookahead || c.cfg.Heuristic == HeuristicPartialMakespan
+
+	for i := 0; i < n; i++ {
+		available[i] = i
+	}
+	rem := n
+	if c.cfg.Summation {
+		clear(c.sums)
+	}
+	if lookahead {
+		clear(c.front)
+	}
+
+	prev := n // prev — предыдущая вершина
+
+	for pos := 0; pos < n; pos++ {
+		// Ограничение списка кандидатов
+		k := rem
+		if c.cfg.CandidateK > 0 && c.cfg.CandidateK < rem {
+			k = c.cfg.CandidateK
+			for t := 0; t < k; t++ {
+				r := t + rng.Intn(rem-t)
+				available[t], available[r] = available[r], available[t]
+			}
+		}
+
+		// Накопление сумм следов оставшихся работ
+		if c.cfg.Summation {
+			for _, j := range available[:rem] {
+				c.sums[j] += c.tau[tauIdx(n, pos, j)]
+			}
+		}
+
+		// Подсчёт весов вероятностей выбора
+		sumW := 0.0
+		for i := 0; i < k; i++ {
+			j := available[i]
+			var t float64
+			switch {
+			case c.cfg.Summation:
+				t = c.sums[j]
+			case position:
+				t = c.tau[tauIdx(n, pos, j)]
+			default:
+				t = c.tau[tauIdx(n, prev, j)]
+			}
+			h := c.eta[j]
+			if lookahead {
+				h = c.lookahead(j)
+			}
+
+			// Формула ACO
+			w := fastPow(t, c.cfg.Alpha) * fastPow(h, c.cfg.Beta)
+			weights[i] = w
+			sumW += w
+		}
+
+		// Выбор следующей работы
+		var chosenIdx int
+		switch {
+		case acs && rng.Float64() < c.cfg.Q0:
+			// Жадный выбор (при равенстве — первый кандидат)
+			for i := 1; i < k; i++ {
+				if weights[i] > weights[chosenIdx] {
+					chosenIdx = i
+				}
+			}
+		case sumW <= 0:
+			chosenIdx = rng.Intn(k)
+		default:
+			r := rng.Float64() * sumW
+			acc := 0.0
+			chosenIdx = k - 1
+			for i := 0; i < k; i++ {
+				acc += weights[i]
+				if r <= acc {
+					chosenIdx = i
+					break
+				}
+			}
+		}
+
+		job := available[chosenIdx]
+		outPerm[pos] = job
+
+		// Локальное обновление ACS: τ ← (1−ξ)·τ + ξ·τ0
+		if acs {
+			idx := c.edge(prev, pos, job)
+			c.tau[idx] = (1-c.cfg.Xi)*c.tau[idx] + c.cfg.Xi*c.tau0
+		}
+		if lookahead {
+			c.extend(job)
+		}
+		prev = job
+
+		// Удаляем выбранную работу из списка доступных
+		available[chosenIdx], available[rem-1] =
+			available[rem-1], available[chosenIdx]
+		rem--
+	}
+}
+
+// update выполняет глобальное обновление феромона по решению perm стоимости cost;
+// bestCost — стоимость лучшего найденного решения (для границ MMAS).
+//
+//	as:   испарение всех следов и отложение Q/cost вдоль perm;
+//	mmas: то же с ограничением следов отрезком [τmin, τmax];
+//	acs:  испарение и отложение только вдоль perm: τ ← (1−ρ)·τ + ρ·Q/cost.
+func (c *colony) update(perm []int, cost, bestCost int) {
+	rho := c.cfg.Rho
+	dep := c.cfg.Q / float64(cost)
+
+	switch c.cfg.Variant {
+	case VariantACS:
+		if !c.initialized {
+			// Уровень τ0 согласуется с масштабом отложения Q/cost
+			c.tau0 = c.cfg.Q / (float64(c.n) * float64(bestCost))
+			for i := range c.tau {
+				c.tau[i] = c.tau0
+			}
+			c.initialized = true
+		}
+		c.forEachEdge(perm, func(idx int) {
+			c.tau[idx] = max((1-rho)*c.tau[idx]+rho*dep, tauFloor)
+		})
+
+	case VariantMMAS:
+		c.updateBounds(bestCost)
+		if !c.initialized {
+			// Следы инициализируются верхней границей
+			for i := range c.tau {
+				c.tau[i] = c.tauMax
+			}
+			c.initialized = true
+		}
+		for i := range c.tau {
+			c.tau[i] *= 1 - rho
+		}
+		c.deposit(perm, dep)
+		for i, t := range c.tau {
+			c.tau[i] = min(max(t, c.tauMin), c.tauMax)
+		}
+
+	default:
+		// Испарение феромона
+		ev := 1.0 - rho
+		for i := range c.tau {
+			c.tau[i] *= ev
+			if c.tau[i] < tauFloor {
+				c.tau[i] = tauFloor
+			}
+		}
+		c.deposit(perm, dep)
+	}
+}
+
+// updateBounds пересчитывает границы MMAS (Stützle, Hoos, 2000):
+// τmax = Q / (ρ·C_best), τmin = τmax·(1 − ⁿ√p_best) / ((n/2 − 1)·ⁿ√p_best).
+func (c *colony) updateBounds(bestCost int) {
+	c.tauMax = c.cfg.Q / (c.cfg.Rho * float64(bestCost))
+	pdec := math.Pow(c.cfg.PBest, 1/float64(c.n))
+	avg := max(float64(c.n)/2-1, 1)
+	c.tauMin = min(c.tauMax*(1-pdec)/(avg*pdec), c.tauMax)
+}
+
+// smooth сглаживает феромон при стагнации: τ ← τ + δ·(τ' − τ), где τ' — τmax
+// для MMAS и τ0 для остальных вариантов; δ = 1 — полная переинициализация.
+func (c *colony) smooth() {
+	target := c.tau0
+	if c.cfg.Variant == VariantMMAS && c.initialized {
+		target = c.tauMax
+	}
+	d := c.cfg.Smoothing
+	for i, t := range c.tau {
+		c.tau[i] = t + d*(target-t)
+	}
+}
+
+// edge возвращает индекс следа постановки работы job в позицию pos после работы prev.
+func (c *colony) edge(prev, pos, job int) int {
+	if c.cfg.Pheromone == PheromonePosition {
+		return tauIdx(c.n, pos, job)
+	}
+	return tauIdx(c.n, prev, job)
+}
+
+// forEachEdge вызывает fn для индексов следов решения perm
+// (в модели sequence — от фиктивного старта до последней работы).
+func (c *colony) forEachEdge(perm []int, fn func(idx int)) {
+	prev := c.n
+	for pos, job := range perm {
+		fn(c.edge(prev, pos, job))
+		prev = job
+	}
+}
+
+// deposit усиливает феромон вдоль решения perm на delta.
+func (c *colony) deposit(perm []int, delta float64) {
+	c.forEachEdge(perm, func(idx int) {
+		c.tau[idx] += delta
+	})
+}
+
+// lookahead — динамическая эвристика в духе NEH для работы job, добавляемой
+// в конец частичной последовательности:
+//
+//	lookahead:        η = (ΣP_job + 1) / (ΣP_job + I + 1), где I — простои станков 2..m в ожидании job;
+//	partial-makespan: η = 1 / (C + 1), где C — makespan частичной последовательности с job.
+//
+// Для гибридного flow-shop стадии рассматриваются как одиночные станки.
+func (c *colony) lookahead(job int) float64 {
+	f, idle, total := 0, 0, 0
+	for k, done := range c.front {
+		if k > 0 && f > done {
+			idle += f - done
+		}
+		f = max(f, done) + c.inst.Time(job, k)
+		total += c.inst.Time(job, k)
+	}
+	if c.cfg.Heuristic == HeuristicPartialMakespan {
+		return 1 / float64(f+1)
+	}
+	return float64(total+1) / float64(total+idle+1)
+}
+
+// extend добавляет работу job в конец частичной последовательности.
+func (c *colony) extend(job int) {
+	f := 0
+	for k, done := range c.front {
+		f = max(f, done) + c.inst.Time(job, k)
+		c.front[k] = f
+	}
+}
@@ -1,6 +1,58 @@
 package aco
 
-import "fmt"
+import (
+	"fmt"
+
+	"flowShop/internal/ls"
+)
+
+// Variant определяет вариант муравьиного алгоритма.
+type Variant string
+
+const (
+	// VariantAS — Ant System: испарение всех следов и отложение вдоль одного пути.
+	VariantAS Variant = "as"
+	// VariantMMAS — MAX–MIN Ant System (Stützle, Hoos, 2000): следы ограничены
+	// отрезком [τmin, τmax], вычисляемым по лучшему makespan.
+	VariantMMAS Variant = "mmas"
+	// VariantACS — Ant Colony System (Dorigo, Gambardella, 1997): псевдослучайное
+	// пропорциональное правило выбора и локальное обновление феромона.
+	VariantACS Variant = "acs"
+)
+
+// DepositRule определяет решение, вдоль которого откладывается феромон.
+type DepositRule string
+
+const (
+	// DepositIteration — лучшее решение итерации.
+	DepositIteration DepositRule = "iteration"
+	// DepositGlobal — лучшее решение за всё время.
+	DepositGlobal DepositRule = "global"
+)
+
+// PheromoneModel определяет смысл феромонной матрицы.
+type PheromoneModel string
+
+const (
+	// PheromoneSequence — след перехода «предыдущая работа → следующая» (как в TSP).
+	PheromoneSequence PheromoneModel = "sequence"
+	// PheromonePosition — след постановки работы в позицию.
+	PheromonePosition PheromoneModel = "position"
+)
+
+// Heuristic определяет эвристическую информацию η при построении решения.
+type Heuristic string
+
+const (
+	// HeuristicTotalTime — статическая η = 1 / (ΣP_j + 1).
+	HeuristicTotalTime Heuristic = "total-time"
+	// HeuristicLookahead — η = (ΣP_j + 1) / (ΣP_j + I_j + 1), где I_j — простои станков
+	// при добавлении работы в конец частичной последовательности (в духе NEH).
+	HeuristicLookahead Heuristic = "lookahead"
+	// HeuristicPartialMakespan — η = 1 / (C_j + 1), где C_j — makespan частичной
+	// последовательности, достроенной работой j (в духе NEH).
+	HeuristicPartialMakespan Heuristic = "partial-makespan"
+)
 
 type Config struct {
 	Iterations       int
@@ -18,6 +70,36 @@ type Config struct {
 	Tau0 float64
 
 	CandidateK int
+
+	// Variant — вариант алгоритма
+	Variant Variant
+	// Deposit — решение, вдоль которого откладывается феромон
+	Deposit DepositRule
+
+	// Pheromone — модель феромона
+	Pheromone PheromoneModel
+	// Summation — суммирующая оценка следов по позициям ≤ текущей (только для position)
+	Summation bool
+	// Heuristic — эвристическая информация
+	Heuristic Heuristic
+
+	// PBest — вероятность построения лучшего решения при сходимости MMAS (для τmin)
+	PBest float64
+
+	// StagnationIters — число итераций без улучшения, после которого феромон
+	// сглаживается (0 — без сглаживания)
+	StagnationIters int
+	// Smoothing — доля сглаживания δ ∈ (0, 1]: τ ← τ + δ·(τmax − τ); 1 — переинициализация
+	Smoothing float64
+
+	// Q0 — вероятность жадного выбора ACS
+	Q0 float64
+	// Xi — коэффициент локального обновления феромона ACS
+	Xi float64
+
+	// LocalSearch — локальный спуск, применяемый к лучшему муравью итерации
+	// перед обновлением феромона; нулевое значение отключает гибридизацию
+	LocalSearch ls.Config
 }
 
 func DefaultConfig() Config {
@@ -36,6 +118,21 @@ func DefaultConfig() Config {
 		Tau0: 1.0,
 
 		CandidateK: 0,
+
+		Variant: VariantAS,
+		Deposit: DepositIteration,
+
+		Pheromone: PheromoneSequence,
+		Summation: false,
+		Heuristic: HeuristicTotalTime,
+
+		PBest: 0.05,
+
+		StagnationIters: 0,
+		Smoothing:       1.0,
+
+		Q0: 0.9,
+		Xi: 0.1,
 	}
 }
 
@@ -87,5 +184,82 @@ func (c Config) Validate() error {
 			c.CandidateK,
 		)
 	}
+	switch c.Variant {
+	case VariantAS:
+		// ok
+	case VariantMMAS:
+		if c.PBest <= 0 || c.PBest >= 1 {
+			return fmt.Errorf(
+				"PBest должно лежать в интервале (0,1) (получено %f)",
+				c.PBest,
+			)
+		}
+	case VariantACS:
+		if c.Q0 < 0 || c.Q0 > 1 {
+			return fmt.Errorf(
+				"Q0 должно лежать в отрезке [0,1] (получено %f)",
+				c.Q0,
+			)
+		}
+		if c.Xi <= 0 || c.Xi >= 1 {
+			return fmt.Errorf(
+				"Xi должно лежать в интервале (0,1) (получено %f)",
+				c.Xi,
+			)
+		}
+	default:
+		return fmt.Errorf(
+			"неизвестный вариант ACO %q",
+			c.Variant,
+		)
+	}
+	switch c.Deposit {
+	case DepositIteration, DepositGlobal:
+		// ok
+	default:
+		return fmt.Errorf(
+			"неизвестное правило отложения феромона %q",
+			c.Deposit,
+		)
+	}
+	switch c.Pheromone {
+	case PheromoneSequence:
+		if c.Summation {
+			return fmt.Errorf("суммирующая оценка требует модели феромона position")
+		}
+	case PheromonePosition:
+		// ok
+	default:
+		return fmt.Errorf(
+			"неизвестная модель феромона %q",
+			c.Pheromone,
+		)
+	}
+	switch c.Heuristic {
+	case HeuristicTotalTime, HeuristicLookahead, HeuristicPartialMakespan:
+		// ok
+	default:
+		return fmt.Errorf(
+			"неизвестная эвристика %q",
+			c.Heuristic,
+		)
+	}
+	if c.StagnationIters < 0 {
+		return fmt.Errorf(
+			"StagnationIters должно быть >= 0 (получено %d)",
+			c.StagnationIters,
+		)
+	}
+	if c.StagnationIters > 0 && (c.Smoothing <= 0 || c.Smoothing > 1) {
+		return fmt.Errorf(
+			"Smoothing должно лежать в интервале (0,1] (получено %f)",
+			c.Smoothing,
+		)
+	}
+	if c.LocalSearch.Enabled() {
+		if err := c.LocalSearch.Validate(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
@@ -0,0 +1,159 @@
+package bench
+
+import (
+	"math"
+	"sort"
+)
+
+// Показатели качества аппроксимации множества Парето.
+// Все критерии минимизируются; точки передаются как срезы одинаковой длины.
+
+// Hypervolume вычисляет объём области, доминируемой front и ограниченной точкой ref.
+// Точки, не доминирующие ref строго, не учитываются.
+// Используется рекурсивное разбиение по последнему критерию (HSO),
+// что достаточно для фронтов с 2–3 критериями.
+func Hypervolume(front [][]float64, ref []float64) float64 {
+	pts := make([][]float64, 0, len(front))
+	for _, p := range front {
+		inside := true
+		for k := range ref {
+			if p[k] >= ref[k] {
+				inside = false
+				break
+			}
+		}
+		if inside {
+			pts = append(pts, p)
+		}
+	}
+	return hso(pts, ref, len(ref))
+}
+
+func hso(pts [][]float64, ref []float64, dims int) float64 {
+	if len(pts) == 0 {
+		return 0
+	}
+	if dims == 1 {
+		lo := pts[0][0]
+		for _, p := range pts[1:] {
+			if p[0] < lo {
+				lo = p[0]
+			}
+		}
+		return ref[0] - lo
+	}
+	k := dims - 1
+	sorted := make([][]float64, len(pts))
+	copy(sorted, pts)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i][k] < sorted[j][k] })
+
+	volume := 0.0
+	for i := range sorted {
+		upper := ref[k]
+		if i+1 < len(sorted) {
+			upper = sorted[i+1][k]
+		}
+		height := upper - sorted[i][k]
+		if height <= 0 {
+			continue
+		}
+		volume += hso(sorted[:i+1], ref, k) * height
+	}
+	return volume
+}
+
+// IGD — инвертированное расстояние поколений: среднее по точкам reference
+// евклидово расстояние до ближайшей точки front (меньше — лучше).
+func IGD(front, reference [][]float64) float64 {
+	if len(reference) == 0 {
+		return 0
+	}
+	if len(front) == 0 {
+		return math.Inf(1)
+	}
+	sum := 0.0
+	for _, r := range reference {
+		best := math.Inf(1)
+		for _, p := range front {
+			d := 0.0
+			for k := range r {
+				diff := p[k] - r[k]
+				d += diff * diff
+			}
+			if d < best {
+				best = d
+			}
+		}
+		sum += math.Sqrt(best)
+	}
+	return sum / float64(len(reference))
+}
+
+// AdditiveEpsilon — аддитивный ε-индикатор: минимальный сдвиг ε, при котором
+// каждая точка reference слабо доминируется некоторой точкой front, сдвинутой на −ε
+// (меньше — лучше, 0 — front покрывает reference).
+func AdditiveEpsilon(front, reference [][]float64) float64 {
+	if len(reference) == 0 {
+		return 0
+	}
+	if len(front) == 0 {
+		return math.Inf(1)
+	}
+	eps := math.Inf(-1)
+	for _, r := range reference {
+		best := math.Inf(1)
+		for _, p := range front {
+			worst := math.Inf(-1)
+			for k := range r {
+				if d := p[k] - r[k]; d > worst {
+					worst = d
+				}
+			}
+			if worst < best {
+				best = worst
+			}
+		}
+		if best > eps {
+			eps = best
+		}
+	}
+	return eps
+}
+
+// normalizer линейно отображает критерии в [0,1] по идеальной и надир-точкам.
+type normalizer struct {
+	ideal []float64
+	nadir []float64
+}
+
+func newNormalizer(points [][]int) normalizer {
+	nObj := len(points[0])
+	n := normalizer{ideal: make([]float64, nObj), nadir: make([]float64, nObj)}
+	for k := 0; k < nObj; k++ {
+		n.ideal[k] = math.Inf(1)
+		n.nadir[k] = math.Inf(-1)
+	}
+	for _, p := range points {
+		for k, v := range p {
+			n.ideal[k] = math.Min(n.ideal[k], float64(v))
+			n.nadir[k] = math.Max(n.nadir[k], float64(v))
+		}
+	}
+	return n
+}
+
+func (n normalizer) apply(points [][]int) [][]float64 {
+	out := make([][]float64, len(points))
+	for i, p := range points {
+		q := make([]float64, len(p))
+		for k, v := range p {
+			span := n.nadir[k] - n.ideal[k]
+			if span <= 0 {
+				span = 1
+			}
+			q[k] = (float64(v) - n.ideal[k]) / span
+		}
+		out[i] = q
+	}
+	return out
+}
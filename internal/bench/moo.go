@@ -0,0 +1,214 @@
+package bench
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"flowShop/internal/opt"
+)
+
+type MOAlgorithm struct {
+	Name    string
+	Factory func(seed int64) opt.MultiOptimizer
+}
+
+// MORecord — агрегированные показатели многокритериальных запусков.
+// Индикаторы считаются в нормированном пространстве критериев относительно
+// эталонного фронта — недоминируемого объединения фронтов всех алгоритмов и запусков.
+// Для HV лучшее значение — максимальное, для IGD и ε — минимальное.
+type MORecord struct {
+	Algo     string
+	Jobs     int
+	Machines int
+	Runs     int
+
+	TimeMeanMs float64
+	TimeStdMs  float64
+
+	FrontSizeMean float64
+
+	HVBest float64
+	HVMean float64
+	HVStd  float64
+
+	IGDBest float64
+	IGDMean float64
+	IGDStd  float64
+
+	EpsBest float64
+	EpsMean float64
+	EpsStd  float64
+}
+
+// RunMOCase запускает все многокритериальные алгоритмы на одной конфигурации
+// и сравнивает их фронты по общему эталону.
+func (r Runner) RunMOCase(ctx context.Context, c Case, algos []MOAlgorithm) ([]MORecord, error) {
+	inst, err := c.instance()
+	if err != nil {
+		return nil, err
+	}
+
+	fronts := make([][][]int, len(algos)) // [алгоритм][запуск] → фронт в виде плоского списка векторов
+	frontRuns := make([][]int, len(algos))
+	timesMs := make([][]float64, len(algos))
+	reference := &opt.Archive{}
+
+	for a, algo := range algos {
+		for i := 0; i < r.Runs; i++ {
+			runSeed := r.BaseSeed + int64(i)
+			op := algo.Factory(runSeed)
+
+			runCtx := ctx
+			cancel := func() {}
+			if r.PerRunTimeout > 0 {
+				runCtx, cancel = context.WithTimeout(ctx, r.PerRunTimeout)
+			}
+			start := time.Now()
+			res, err := op.SolvePareto(runCtx, inst)
+			dur := time.Since(start)
+			cancel()
+
+			if err != nil && runCtx.Err() != nil {
+				return nil, fmt.Errorf("%s run %d: cancelled/timeout: %w", algo.Name, i, err)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("%s run %d: solve error: %w", algo.Name, i, err)
+			}
+			if len(res.Front) == 0 {
+				return nil, fmt.Errorf("%s run %d: empty front", algo.Name, i)
+			}
+
+			for _, sol := range res.Front {
+				fronts[a] = append(fronts[a], sol.Objectives)
+				reference.Add(sol.Permutation, sol.Objectives)
+			}
+			frontRuns[a] = append(frontRuns[a], len(res.Front))
+			timesMs[a] = append(timesMs[a], float64(dur.Microseconds())/1000.0)
+		}
+	}
+
+	refPoints := make([][]int, len(reference.Solutions))
+	for i, s := range reference.Solutions {
+		refPoints[i] = s.Objectives
+	}
+	norm := newNormalizer(refPoints)
+	refNorm := norm.apply(refPoints)
+	hvRef := make([]float64, len(refPoints[0]))
+	for k := range hvRef {
+		hvRef[k] = 1.1
+	}
+
+	records := make([]MORecord, 0, len(algos))
+	for a, algo := range algos {
+		hv := make([]float64, 0, r.Runs)
+		igd := make([]float64, 0, r.Runs)
+		eps := make([]float64, 0, r.Runs)
+		sizes := make([]float64, 0, r.Runs)
+
+		offset := 0
+		for _, size := range frontRuns[a] {
+			front := norm.apply(fronts[a][offset : offset+size])
+			offset += size
+
+			hv = append(hv, Hypervolume(front, hvRef))
+			igd = append(igd, IGD(front, refNorm))
+			eps = append(eps, AdditiveEpsilon(front, refNorm))
+			sizes = append(sizes, float64(size))
+		}
+
+		hvStats := CalcFloatStats(hv)
+		hvBest := math.Inf(-1)
+		for _, v := range hv {
+			hvBest = math.Max(hvBest, v)
+		}
+		igdStats := CalcFloatStats(igd)
+		epsStats := CalcFloatStats(eps)
+		tStats := CalcFloatStats(timesMs[a])
+
+		records = append(records, MORecord{
+			Algo:     algo.Name,
+			Jobs:     c.Jobs,
+			Machines: c.Machines,
+			Runs:     r.Runs,
+
+			TimeMeanMs: tStats.Mean,
+			TimeStdMs:  tStats.Std,
+
+			FrontSizeMean: CalcFloatStats(sizes).Mean,
+
+			HVBest: hvBest,
+			HVMean: hvStats.Mean,
+			HVStd:  hvStats.Std,
+
+			IGDBest: igdStats.Best,
+			IGDMean: igdStats.Mean,
+			IGDStd:  igdStats.Std,
+
+			EpsBest: epsStats.Best,
+			EpsMean: epsStats.Mean,
+			EpsStd:  epsStats.Std,
+		})
+	}
+	return records, nil
+}
+
+func WriteMOCSV(path string, records []MORecord) error {
+	if err := os.MkdirAll(dirOf(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	defer w.Flush()
+
+	header := []string{
+		"algo", "jobs", "machines", "runs",
+		"time_mean_ms", "time_std_ms",
+		"front_size_mean",
+		"hv_best", "hv_mean", "hv_std",
+		"igd_best", "igd_mean", "igd_std",
+		"eps_best", "eps_mean", "eps_std",
+	}
+	if err := w.Write(header); err != nil {
+		return err
+	}
+
+	for _, r := range records {
+		row := []string{
+			r.Algo,
+			itoa(r.Jobs),
+			itoa(r.Machines),
+			itoa(r.Runs),
+
+			ftoa(r.TimeMeanMs),
+			ftoa(r.TimeStdMs),
+
+			ftoa(r.FrontSizeMean),
+
+			ftoa(r.HVBest),
+			ftoa(r.HVMean),
+			ftoa(r.HVStd),
+
+			ftoa(r.IGDBest),
+			ftoa(r.IGDMean),
+			ftoa(r.IGDStd),
+
+			ftoa(r.EpsBest),
+			ftoa(r.EpsMean),
+			ftoa(r.EpsStd),
+		}
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+
+	return w.Error()
+}
@@ -14,12 +14,21 @@ import (
 type Algorithm struct {
 	Name    string
 	Factory func(seed int64) opt.Optimizer
+	// Deterministic — результат не зависит от сида; такой алгоритм запускается один раз
+	Deterministic bool
+	// Makespan извлекает учитываемое значение makespan из результата (nil — res.Makespan)
+	Makespan func(res opt.Result) int
 }
 
 type Case struct {
 	Jobs         int
 	Machines     int
 	InstanceSeed int64
+
+	// StageMachines — число параллельных станков на стадиях (nil — классический PFSP).
+	StageMachines []int
+	// Assignment — правило назначения станков для гибридного flow-shop.
+	Assignment flowshop.AssignmentRule
 }
 
 type Record struct {
@@ -35,6 +44,12 @@ type Record struct {
 	MakespanBest int
 	MakespanMean float64
 	MakespanStd  float64
+
+	// LowerBound — нижняя оценка makespan экземпляра (Тайар + двухмашинные оценки);
+	// GapLB*Pct — относительное отклонение makespan от неё в процентах
+	LowerBound   int
+	GapLBBestPct float64
+	GapLBMeanPct float64
 }
 
 type Runner struct {
@@ -43,14 +58,33 @@ type Runner struct {
 	PerRunTimeout time.Duration // 0 = no timeout
 }
 
+// instance генерирует экземпляр задачи для конфигурации.
+// Директивные сроки генерируются отдельным генератором и не влияют на времена обработки.
+func (c Case) instance() (*flowshop.Instance, error) {
+	inst := flowshop.RandomInstance(c.Jobs, c.Machines, 1, 99, randForSeed(c.InstanceSeed))
+	inst.StageMachines = c.StageMachines
+	inst.Assignment = c.Assignment
+	inst.DueDates = flowshop.RandomDueDates(inst, 0.3, 0.6, randForSeed(c.InstanceSeed+1))
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst, nil
+}
+
 func (r Runner) RunCase(ctx context.Context, c Case, algo Algorithm) (Record, error) {
-	instRng := randForSeed(c.InstanceSeed)
-	inst := flowshop.RandomInstance(c.Jobs, c.Machines, 1, 99, instRng)
+	inst, err := c.instance()
+	if err != nil {
+		return Record{}, err
+	}
 
-	makespans := make([]int, 0, r.Runs)
-	timesMs := make([]float64, 0, r.Runs)
+	runs := r.Runs
+	if algo.Deterministic {
+		runs = 1
+	}
+	makespans := make([]int, 0, runs)
+	timesMs := make([]float64, 0, runs)
 
-	for i := 0; i < r.Runs; i++ {
+	for i := 0; i < runs; i++ {
 		runSeed := r.BaseSeed + int64(i)
 
 		op := algo.Factory(runSeed)
@@ -75,18 +109,23 @@ func (r Runner) RunCase(ctx context.Context, c Case, algo Algorithm) (Record, er
 			return Record{}, fmt.Errorf("run %d: invalid permutation length %d (want %d)", i, len(res.Permutation), inst.Jobs)
 		}
 
-		makespans = append(makespans, res.Makespan)
+		ms := res.Makespan
+		if algo.Makespan != nil {
+			ms = algo.Makespan(res)
+		}
+		makespans = append(makespans, ms)
 		timesMs = append(timesMs, float64(dur.Microseconds())/1000.0)
 	}
 
 	msStats := CalcIntStats(makespans)
 	tStats := CalcFloatStats(timesMs)
+	lb := flowshop.LowerBoundWith(inst, flowshop.LowerBoundOptions{TwoMachine: true}).Best
 
 	return Record{
 		Algo:     algo.Name,
 		Jobs:     c.Jobs,
 		Machines: c.Machines,
-		Runs:     r.Runs,
+		Runs:     runs,
 
 		TimeBestMs: tStats.Best,
 		TimeMeanMs: tStats.Mean,
@@ -95,6 +134,10 @@ func (r Runner) RunCase(ctx context.Context, c Case, algo Algorithm) (Record, er
 		MakespanBest: msStats.Best,
 		MakespanMean: msStats.Mean,
 		MakespanStd:  msStats.Std,
+
+		LowerBound:   lb,
+		GapLBBestPct: gapPct(float64(msStats.Best), lb),
+		GapLBMeanPct: gapPct(msStats.Mean, lb),
 	}, nil
 }
 
@@ -115,6 +158,7 @@ func WriteCSV(path string, records []Record) error {
 		"algo", "jobs", "machines", "runs",
 		"time_best_ms", "time_mean_ms", "time_std_ms",
 		"makespan_best", "makespan_mean", "makespan_std",
+		"lower_bound", "gap_lb_best_pct", "gap_lb_mean_pct",
 	}
 	if err := w.Write(header); err != nil {
 		return err
@@ -134,6 +178,10 @@ func WriteCSV(path string, records []Record) error {
 			itoa(r.MakespanBest),
 			ftoa(r.MakespanMean),
 			ftoa(r.MakespanStd),
+
+			itoa(r.LowerBound),
+			ftoa(r.GapLBBestPct),
+			ftoa(r.GapLBMeanPct),
 		}
 		if err := w.Write(row); err != nil {
 			return err
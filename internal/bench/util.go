@@ -23,3 +23,11 @@ func itoa(v int) string { return strconv.Itoa(v) }
 func ftoa(v float64) string {
 	return strconv.FormatFloat(v, 'f', 6, 64)
 }
+
+// gapPct возвращает отклонение value от нижней оценки lb в процентах.
+func gapPct(value float64, lb int) float64 {
+	if lb <= 0 {
+		return 0
+	}
+	return 100 * (value - float64(lb)) / float64(lb)
+}
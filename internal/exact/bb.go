@@ -0,0 +1,416 @@
+// Package exact содержит точный метод ветвей и границ для PFSP небольшой размерности.
+package exact
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"flowShop/internal/flowshop"
+	"flowShop/internal/heur"
+	"flowShop/internal/opt"
+)
+
+// Solver — метод ветвей и границ по частичным перестановкам (ветвление с начала
+// последовательности, поиск в глубину с упорядочиванием потомков по нижней оценке).
+// Начальная верхняя граница — решение NEH. При исчерпании лимитов поиск
+// останавливается, а в Meta сообщается доказанный разрыв между найденным
+// решением и нижней оценкой по неисследованным узлам.
+type Solver struct {
+	Cfg Config
+}
+
+// New возвращает новый солвер с валидацией конфигурации.
+func New(cfg Config) (*Solver, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &Solver{Cfg: cfg}, nil
+}
+
+// Solve — реализация метода ветвей и границ.
+func (s *Solver) Solve(ctx context.Context, inst *flowshop.Instance) (opt.Result, error) {
+	start := time.Now()
+
+	if err := inst.Validate(); err != nil {
+		return opt.Result{}, err
+	}
+	if inst.IsHybrid() {
+		return opt.Result{}, fmt.Errorf("метод ветвей и границ не поддерживает гибридный flow-shop: %w", opt.ErrUnsupported)
+	}
+	if err := s.Cfg.Validate(); err != nil {
+		return opt.Result{}, err
+	}
+
+	// Начальная верхняя граница — NEH
+	neh, nehEvals, err := heur.NEHSequence(ctx, inst)
+	if err != nil {
+		return opt.Result{}, err
+	}
+	eval, err := flowshop.NewEvaluator(inst)
+	if err != nil {
+		return opt.Result{}, err
+	}
+
+	b := newBranch(inst, s.Cfg, ctx, start)
+	b.ub = eval.MustMakespan(neh)
+	copy(b.best, neh)
+	initialUB := b.ub
+
+	rootLB := b.rootBound()
+	lb := rootLB
+	if rootLB < b.ub {
+		b.search(0)
+		lb = b.lb
+		if !b.stopped {
+			lb = b.ub
+		}
+	}
+	// Любая оценка экземпляра тоже ограничивает оптимум снизу
+	lb = max(lb, flowshop.LowerBoundWith(inst, flowshop.LowerBoundOptions{TwoMachine: true}).Best)
+	if lb > b.ub {
+		lb = b.ub
+	}
+
+	gap := 0.0
+	if b.ub > 0 {
+		gap = 100 * float64(b.ub-lb) / float64(b.ub)
+	}
+	meta := map[string]any{
+		"optimal":             lb == b.ub,
+		"lower_bound":         lb,
+		"upper_bound":         b.ub,
+		"root_lower_bound":    rootLB,
+		"initial_upper_bound": initialUB,
+		"gap_pct":             gap,
+		"nodes":               b.nodes,
+		"bound":               string(s.Cfg.Bound),
+	}
+	if b.stopReason != "" {
+		meta["stopped"] = b.stopReason
+	}
+
+	res := opt.Result{
+		Permutation: b.best,
+		Makespan:    b.ub,
+		Evaluations: nehEvals + b.nodes,
+		Iterations:  b.nodes,
+		Duration:    time.Since(start),
+		Meta:        meta,
+	}
+	if b.stopReason == "context" {
+		return res, ctx.Err()
+	}
+	return res, nil
+}
+
+// branch хранит состояние поиска и буферы по уровням дерева.
+type branch struct {
+	inst *flowshop.Instance
+	cfg  Config
+	ctx  context.Context
+
+	start time.Time
+
+	n, m int
+
+	// tail[j*m+k] — сумма времён работы j на станках после k
+	tail []int
+	// total[j] — суммарное время работы j; minFL[j] = min(p_j,0, p_j,m−1)
+	total []int
+	minFL []int
+
+	scheduled []bool
+	sumU      []int // суммарная нагрузка станков по незапланированным работам
+
+	prefix []int
+	comp   [][]int // comp[d] — времена завершения префикса длины d на станках
+
+	childJob [][]int
+	childLB  [][]int
+	cursor   []int
+
+	// Статистики незапланированных работ (два минимума/максимума для исключения потомка)
+	stat nodeStats
+
+	ub   int
+	best []int
+
+	lb         int // нижняя оценка по неисследованным узлам при остановке
+	nodes      int
+	stopped    bool
+	stopReason string
+}
+
+// nodeStats — две наименьших величины по каждому станку и две наибольших
+// величины total−minFL; позволяют за O(m) получить статистику U без одной работы.
+type nodeStats struct {
+	tailMin1, tailMin2 []int
+	tailArg            []int
+	headMin1, headMin2 []int
+	headArg            []int
+	jobMax1, jobMax2   int
+	jobArg             int
+	sumMinFL           int
+}
+
+func newBranch(inst *flowshop.Instance, cfg Config, ctx context.Context, start time.Time) *branch {
+	n, m := inst.Jobs, inst.Machines
+	b := &branch{
+		inst:      inst,
+		cfg:       cfg,
+		ctx:       ctx,
+		start:     start,
+		n:         n,
+		m:         m,
+		tail:      make([]int, n*m),
+		total:     make([]int, n),
+		minFL:     make([]int, n),
+		scheduled: make([]bool, n),
+		sumU:      make([]int, m),
+		prefix:    make([]int, n),
+		comp:      make([][]int, n+1),
+		childJob:  make([][]int, n),
+		childLB:   make([][]int, n),
+		cursor:    make([]int, n),
+		best:      make([]int, n),
+		stat: nodeStats{
+			tailMin1: make([]int, m),
+			tailMin2: make([]int, m),
+			tailArg:  make([]int, m),
+			headMin1: make([]int, m),
+			headMin2: make([]int, m),
+			headArg:  make([]int, m),
+		},
+	}
+	for j := 0; j < n; j++ {
+		acc := 0
+		for k := m - 1; k >= 0; k-- {
+			b.tail[j*m+k] = acc
+			acc += inst.Time(j, k)
+		}
+		b.total[j] = acc
+		b.minFL[j] = min(inst.Time(j, 0), inst.Time(j, m-1))
+		for k := 0; k < m; k++ {
+			b.sumU[k] += inst.Time(j, k)
+		}
+	}
+	for d := range b.comp {
+		b.comp[d] = make([]int, m)
+	}
+	for d := 0; d < n; d++ {
+		b.childJob[d] = make([]int, 0, n-d)
+		b.childLB[d] = make([]int, 0, n-d)
+	}
+	return b
+}
+
+// collectStats вычисляет статистики по незапланированным работам.
+func (b *branch) collectStats() {
+	st := &b.stat
+	for k := 0; k < b.m; k++ {
+		st.tailMin1[k], st.tailMin2[k], st.tailArg[k] = math.MaxInt, math.MaxInt, -1
+		st.headMin1[k], st.headMin2[k], st.headArg[k] = math.MaxInt, math.MaxInt, -1
+	}
+	st.jobMax1, st.jobMax2, st.jobArg = math.MinInt, math.MinInt, -1
+	st.sumMinFL = 0
+
+	for j := 0; j < b.n; j++ {
+		if b.scheduled[j] {
+			continue
+		}
+		for k := 0; k < b.m; k++ {
+			t := b.tail[j*b.m+k]
+			if t < st.tailMin1[k] {
+				st.tailMin2[k] = st.tailMin1[k]
+				st.tailMin1[k], st.tailArg[k] = t, j
+			} else if t < st.tailMin2[k] {
+				st.tailMin2[k] = t
+			}
+			h := b.inst.Time(j, k)
+			if h < st.headMin1[k] {
+				st.headMin2[k] = st.headMin1[k]
+				st.headMin1[k], st.headArg[k] = h, j
+			} else if h < st.headMin2[k] {
+				st.headMin2[k] = h
+			}
+		}
+		v := b.total[j] - b.minFL[j]
+		if v > st.jobMax1 {
+			st.jobMax2 = st.jobMax1
+			st.jobMax1, st.jobArg = v, j
+		} else if v > st.jobMax2 {
+			st.jobMax2 = v
+		}
+		st.sumMinFL += b.minFL[j]
+	}
+}
+
+// bound вычисляет нижнюю оценку узла с временами завершения префикса comp
+// при множестве незапланированных работ U \ {excl} (excl = −1 — без исключения).
+// remaining — число работ в этом множестве.
+func (b *branch) bound(comp []int, excl, remaining int) int {
+	if remaining == 0 {
+		return comp[b.m-1]
+	}
+	st := &b.stat
+	pick := func(min1, min2 []int, arg []int, k int) int {
+		if arg[k] == excl {
+			return min2[k]
+		}
+		return min1[k]
+	}
+
+	// Машинная оценка Игналла–Шрейджа с уточнёнными головами:
+	// r_k = max(C_k, r_{k−1} + min p_{j,k−1}), LB = max_k (r_k + Σ p_jk + min tail_jk)
+	lb := 0
+	r := 0
+	for k := 0; k < b.m; k++ {
+		load := b.sumU[k]
+		if excl >= 0 {
+			load -= b.inst.Time(excl, k)
+		}
+		if k == 0 {
+			r = comp[0]
+		} else {
+			r = max(comp[k], r+pick(st.headMin1, st.headMin2, st.headArg, k-1))
+		}
+		if v := r + load + pick(st.tailMin1, st.tailMin2, st.tailArg, k); v > lb {
+			lb = v
+		}
+	}
+
+	if b.cfg.Bound == BoundMachineJob {
+		// Работная оценка: каждая другая работа либо предшествует j на первом станке,
+		// либо следует за ней на последнем
+		sumFL := st.sumMinFL
+		jobMax := st.jobMax1
+		if excl >= 0 {
+			sumFL -= b.minFL[excl]
+			if st.jobArg == excl {
+				jobMax = st.jobMax2
+			}
+		}
+		if v := comp[0] + sumFL + jobMax; v > lb {
+			lb = v
+		}
+	}
+	return lb
+}
+
+func (b *branch) rootBound() int {
+	b.collectStats()
+	return b.bound(b.comp[0], -1, b.n)
+}
+
+// checkLimits проверяет отмену контекста и лимиты поиска.
+func (b *branch) checkLimits() bool {
+	if b.cfg.NodeLimit > 0 && b.nodes >= b.cfg.NodeLimit {
+		b.stopReason = "node_limit"
+		return true
+	}
+	if b.nodes&1023 == 0 {
+		if b.ctx.Err() != nil {
+			b.stopReason = "context"
+			return true
+		}
+		if b.cfg.TimeLimit > 0 && time.Since(b.start) >= b.cfg.TimeLimit {
+			b.stopReason = "time_limit"
+			return true
+		}
+	}
+	return false
+}
+
+// search раскрывает узел с префиксом длины depth.
+// Возвращает false, если поиск остановлен по лимиту.
+func (b *branch) search(depth int) bool {
+	b.nodes++
+	if b.checkLimits() {
+		b.stopped = true
+		b.lb = b.ub
+		return false
+	}
+
+	comp := b.comp[depth]
+	next := b.comp[depth+1]
+	remaining := b.n - depth - 1
+
+	// Оценка всех потомков
+	b.collectStats()
+	jobs := b.childJob[depth][:0]
+	lbs := b.childLB[depth][:0]
+	for j := 0; j < b.n; j++ {
+		if b.scheduled[j] {
+			continue
+		}
+		b.extend(comp, next, j)
+		lb := b.bound(next, j, remaining)
+		if lb >= b.ub {
+			continue
+		}
+		// Вставка с сохранением порядка по (LB, номер работы)
+		i := len(jobs)
+		jobs = append(jobs, j)
+		lbs = append(lbs, lb)
+		for i > 0 && lbs[i-1] > lb {
+			jobs[i], lbs[i] = jobs[i-1], lbs[i-1]
+			i--
+		}
+		jobs[i], lbs[i] = j, lb
+	}
+	b.childJob[depth] = jobs
+	b.childLB[depth] = lbs
+
+	for i := range jobs {
+		j := jobs[i]
+		if lbs[i] >= b.ub {
+			// Потомки упорядочены по оценке — остальные тоже отсекаются
+			break
+		}
+		b.extend(comp, next, j)
+		b.prefix[depth] = j
+
+		if remaining == 0 {
+			// Лист: полная перестановка
+			if next[b.m-1] < b.ub {
+				b.ub = next[b.m-1]
+				copy(b.best, b.prefix)
+			}
+			continue
+		}
+
+		b.schedule(j, true)
+		ok := b.search(depth + 1)
+		b.schedule(j, false)
+		if !ok {
+			// Неисследованные узлы текущего уровня, включая прерванный
+			for _, lb := range lbs[i:] {
+				b.lb = min(b.lb, lb)
+			}
+			return false
+		}
+	}
+	return true
+}
+
+// extend вычисляет времена завершения префикса comp, дополненного работой job.
+func (b *branch) extend(comp, next []int, job int) {
+	next[0] = comp[0] + b.inst.Time(job, 0)
+	for k := 1; k < b.m; k++ {
+		next[k] = max(comp[k], next[k-1]) + b.inst.Time(job, k)
+	}
+}
+
+// schedule помечает работу как запланированную (или снимает отметку) и обновляет нагрузку станков.
+func (b *branch) schedule(job int, on bool) {
+	b.scheduled[job] = on
+	for k := 0; k < b.m; k++ {
+		if on {
+			b.sumU[k] -= b.inst.Time(job, k)
+		} else {
+			b.sumU[k] += b.inst.Time(job, k)
+		}
+	}
+}
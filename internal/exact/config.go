@@ -0,0 +1,58 @@
+package exact
+
+import (
+	"fmt"
+	"time"
+)
+
+// Bound — нижняя оценка, используемая при ветвлении.
+type Bound string
+
+const (
+	// BoundMachine — машинная оценка Игналла–Шрейджа с уточнёнными головами.
+	BoundMachine Bound = "machine"
+	// BoundMachineJob — максимум из машинной и работной оценок.
+	BoundMachineJob Bound = "machine-job"
+)
+
+type Config struct {
+	// NodeLimit — максимальное число узлов дерева (0 — без ограничения)
+	NodeLimit int
+	// TimeLimit — ограничение времени поиска (0 — без ограничения)
+	TimeLimit time.Duration
+
+	Bound Bound
+}
+
+func DefaultConfig() Config {
+	return Config{
+		NodeLimit: 0,
+		TimeLimit: 0,
+		Bound:     BoundMachineJob,
+	}
+}
+
+func (c Config) Validate() error {
+	if c.NodeLimit < 0 {
+		return fmt.Errorf(
+			"NodeLimit должно быть >= 0 (получено %d)",
+			c.NodeLimit,
+		)
+	}
+	if c.TimeLimit < 0 {
+		return fmt.Errorf(
+			"TimeLimit должно быть >= 0 (получено %s)",
+			c.TimeLimit,
+		)
+	}
+	switch c.Bound {
+	case BoundMachine, BoundMachineJob:
+		// ok
+	default:
+		return fmt.Errorf(
+			"неизвестный тип нижней оценки %q",
+			c.Bound,
+		)
+	}
+	return nil
+}
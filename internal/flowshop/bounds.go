@@ -0,0 +1,94 @@
+package flowshop
+
+// LowerBounds — нижние оценки makespan экземпляра.
+type LowerBounds struct {
+	// Machine — машинная оценка Тайара: max_m (min голова + нагрузка m + min хвост)
+	Machine int
+	// Job — работная оценка: max_j Σ_m p_jm
+	Job int
+	// TwoMachine — оценка по парам станков (правило Джонсона с задержками);
+	// 0, если не вычислялась
+	TwoMachine int
+	// Best — максимум из вычисленных оценок
+	Best int
+}
+
+type LowerBoundOptions struct {
+	// TwoMachine включает двухмашинные оценки: для каждой пары станков u < v
+	// задача с задержками (суммой времён между u и v) решается точно по
+	// правилу Джонсона–Миттена. Стоимость — O(m²·n·log n).
+	// Для гибридного flow-shop не вычисляется.
+	TwoMachine bool
+}
+
+// LowerBound возвращает классические оценки Тайара (машинную и работную).
+func LowerBound(inst *Instance) LowerBounds {
+	return LowerBoundWith(inst, LowerBoundOptions{})
+}
+
+// LowerBoundWith возвращает нижние оценки с дополнительными опциями.
+// Для гибридного flow-shop машинная оценка считается по стадиям:
+// нагрузка стадии делится на число станков (с округлением вверх).
+func LowerBoundWith(inst *Instance, opts LowerBoundOptions) LowerBounds {
+	n, m := inst.Jobs, inst.Machines
+
+	// head[j*m+k] — сумма времён работы j до станка k, tail — после
+	head := make([]int, n*m)
+	tail := make([]int, n*m)
+	for j := 0; j < n; j++ {
+		acc := 0
+		for k := 0; k < m; k++ {
+			head[j*m+k] = acc
+			acc += inst.Time(j, k)
+		}
+		acc = 0
+		for k := m - 1; k >= 0; k-- {
+			tail[j*m+k] = acc
+			acc += inst.Time(j, k)
+		}
+	}
+
+	var lb LowerBounds
+	for k := 0; k < m; k++ {
+		minHead, minTail, load := head[k], tail[k], 0
+		for j := 0; j < n; j++ {
+			minHead = min(minHead, head[j*m+k])
+			minTail = min(minTail, tail[j*m+k])
+			load += inst.Time(j, k)
+		}
+		size := inst.StageSize(k)
+		load = (load + size - 1) / size
+		lb.Machine = max(lb.Machine, minHead+load+minTail)
+	}
+	for j := 0; j < n; j++ {
+		lb.Job = max(lb.Job, head[j*m+m-1]+inst.Time(j, m-1))
+	}
+	lb.Best = max(lb.Machine, lb.Job)
+
+	if opts.TwoMachine && !inst.IsHybrid() && m >= 2 {
+		a := make([]int, n)
+		b := make([]int, n)
+		lag := make([]int, n)
+		a2 := make([]int, n)
+		b2 := make([]int, n)
+		for u := 0; u < m-1; u++ {
+			for v := u + 1; v < m; v++ {
+				minHead, minTail := head[u], tail[v]
+				for j := 0; j < n; j++ {
+					a[j] = inst.Time(j, u)
+					b[j] = inst.Time(j, v)
+					lag[j] = head[j*m+v] - head[j*m+u] - a[j]
+					a2[j] = a[j] + lag[j]
+					b2[j] = b[j] + lag[j]
+					minHead = min(minHead, head[j*m+u])
+					minTail = min(minTail, tail[j*m+v])
+				}
+				order := JohnsonOrder(a2, b2)
+				v2 := minHead + TwoMachineMakespan(order, a, b, lag) + minTail
+				lb.TwoMachine = max(lb.TwoMachine, v2)
+			}
+		}
+		lb.Best = max(lb.Best, lb.TwoMachine)
+	}
+	return lb
+}
@@ -0,0 +1,61 @@
+package flowshop
+
+// Block — критический блок: операции работ perm[Start..End] на станке Machine,
+// лежащие на критическом пути и выполняемые подряд без простоев.
+type Block struct {
+	Machine    int
+	Start, End int
+}
+
+// CriticalBlocks разбивает критический путь перестановочного расписания perm
+// на блоки в порядке от первого станка к последнему, переиспользуя буфер blocks.
+// Соседние блоки имеют общую угловую работу (End одного равен Start следующего).
+// При равенстве времён путь продолжается по станку (к предыдущей работе).
+// Для гибридного flow-shop критический путь не определён, и возвращается blocks[:0].
+func (e *Evaluator) CriticalBlocks(perm []int, blocks []Block) []Block {
+	blocks = blocks[:0]
+	if e.hybrid != nil || len(perm) == 0 {
+		return blocks
+	}
+
+	n, m := len(perm), e.inst.Machines
+	if cap(e.heads) < n*m {
+		e.heads = make([]int, n*m)
+	}
+	c := e.heads[:n*m]
+
+	// c[i·m+k] — завершение работы perm[i] на станке k
+	for i, job := range perm {
+		row := i * m
+		for k := 0; k < m; k++ {
+			start := 0
+			if i > 0 {
+				start = c[row-m+k]
+			}
+			if k > 0 && c[row+k-1] > start {
+				start = c[row+k-1]
+			}
+			c[row+k] = start + e.inst.Time(job, k)
+		}
+	}
+
+	// Обратный проход от последней операции; блоки собираются в обратном порядке
+	i, k := n-1, m-1
+	end := i
+	for i > 0 || k > 0 {
+		if i > 0 && (k == 0 || c[(i-1)*m+k] >= c[i*m+k-1]) {
+			i--
+			continue
+		}
+		// Переход на предыдущий станок завершает блок станка k
+		blocks = append(blocks, Block{Machine: k, Start: i, End: end})
+		k--
+		end = i
+	}
+	blocks = append(blocks, Block{Machine: 0, Start: 0, End: end})
+
+	for a, b := 0, len(blocks)-1; a < b; a, b = a+1, b-1 {
+		blocks[a], blocks[b] = blocks[b], blocks[a]
+	}
+	return blocks
+}
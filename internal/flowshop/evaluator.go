@@ -5,13 +5,27 @@ import "fmt"
 type Evaluator struct {
 	inst              *Instance
 	machineCompletion []int
+	jobCompletion     []int
+
+	// Матрица времён завершения для построения критического пути
+	heads []int
+
+	// Отметки работ для проверки перестановки без выделения памяти (Objectives)
+	seen []bool
+
+	// Буферы декодера гибридного flow-shop (nil для классического PFSP)
+	hybrid *hybridDecoder
 }
 
 func NewEvaluator(inst *Instance) (*Evaluator, error) {
 	if err := inst.Validate(); err != nil {
 		return nil, err
 	}
-	return &Evaluator{inst: inst, machineCompletion: make([]int, inst.Machines)}, nil
+	e := &Evaluator{inst: inst, machineCompletion: make([]int, inst.Machines)}
+	if inst.IsHybrid() {
+		e.hybrid = newHybridDecoder(inst)
+	}
+	return e, nil
 }
 
 func (e *Evaluator) Makespan(perm []int) (int, error) {
@@ -25,6 +39,10 @@ func (e *Evaluator) Makespan(perm []int) (int, error) {
 		return 0, err
 	}
 
+	if e.hybrid != nil {
+		return e.hybrid.decode(perm, nil), nil
+	}
+
 	for m := range e.machineCompletion {
 		e.machineCompletion[m] = 0
 	}
@@ -44,6 +62,25 @@ func (e *Evaluator) Makespan(perm []int) (int, error) {
 	return e.machineCompletion[e.inst.Machines-1], nil
 }
 
+// PartialMakespan вычисляет makespan расписания только для работ seq
+// (например, частичной последовательности в конструктивных эвристиках).
+// Корректность seq (различные работы из [0, Jobs)) не проверяется.
+func (e *Evaluator) PartialMakespan(seq []int) int {
+	if e.hybrid != nil {
+		return e.hybrid.decode(seq, nil)
+	}
+	for m := range e.machineCompletion {
+		e.machineCompletion[m] = 0
+	}
+	for _, job := range seq {
+		e.machineCompletion[0] += e.inst.Time(job, 0)
+		for m := 1; m < e.inst.Machines; m++ {
+			e.machineCompletion[m] = max(e.machineCompletion[m-1], e.machineCompletion[m]) + e.inst.Time(job, m)
+		}
+	}
+	return e.machineCompletion[e.inst.Machines-1]
+}
+
 func (e *Evaluator) MustMakespan(perm []int) int {
 	ms, err := e.Makespan(perm)
 	if err != nil {
@@ -51,3 +88,23 @@ func (e *Evaluator) MustMakespan(perm []int) int {
 	}
 	return ms
 }
+
+// Schedule декодирует перестановку в полное расписание с указанием станка
+// для каждой операции. Для классического PFSP номер станка внутри стадии всегда 0.
+func (e *Evaluator) Schedule(perm []int) (Schedule, error) {
+	if e == nil || e.inst == nil {
+		return Schedule{}, fmt.Errorf("nil evaluator")
+	}
+	if err := ValidatePermutation(perm, e.inst.Jobs); err != nil {
+		return Schedule{}, err
+	}
+	dec := e.hybrid
+	if dec == nil {
+		// Классический PFSP — частный случай списочного декодирования
+		// с одним станком на стадии, поэтому используем тот же декодер.
+		dec = newHybridDecoder(e.inst)
+	}
+	ops := make([]Operation, 0, e.inst.Jobs*e.inst.Machines)
+	ms := dec.decode(perm, &ops)
+	return Schedule{Makespan: ms, Operations: ops}, nil
+}
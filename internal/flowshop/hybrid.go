@@ -0,0 +1,153 @@
+package flowshop
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// Operation — одна операция расписания: работа Job на стадии Stage
+// выполнялась на станке Machine (номер внутри стадии) в интервале [Start, End).
+type Operation struct {
+	Job     int
+	Stage   int
+	Machine int
+	Start   int
+	End     int
+}
+
+// Schedule — результат декодирования перестановки.
+// Операции упорядочены по стадиям, внутри стадии — по порядку запуска.
+type Schedule struct {
+	Makespan   int
+	Operations []Operation
+}
+
+// hybridDecoder реализует списочное декодирование перестановки для гибридного flow-shop.
+// На первой стадии работы запускаются в порядке перестановки, на последующих —
+// в порядке готовности (завершения на предыдущей стадии), при равенстве —
+// в порядке перестановки. Станок внутри стадии выбирается по Instance.Assignment.
+// При одном станке на каждой стадии декодирование совпадает с классическим PFSP.
+type hybridDecoder struct {
+	inst *Instance
+
+	free   []int // момент освобождения каждого физического станка
+	offset []int // индекс первого станка стадии в free
+
+	ready []int // момент завершения работы на предыдущей стадии
+	rank  []int // позиция работы в перестановке
+	order readyOrder
+}
+
+func newHybridDecoder(inst *Instance) *hybridDecoder {
+	offset := make([]int, inst.Machines+1)
+	for s := 0; s < inst.Machines; s++ {
+		offset[s+1] = offset[s] + inst.StageSize(s)
+	}
+	d := &hybridDecoder{
+		inst:   inst,
+		free:   make([]int, offset[inst.Machines]),
+		offset: offset,
+		ready:  make([]int, inst.Jobs),
+		rank:   make([]int, inst.Jobs),
+	}
+	d.order = readyOrder{jobs: make([]int, inst.Jobs), ready: d.ready, rank: d.rank}
+	return d
+}
+
+// decode возвращает makespan; если ops != nil, операции дописываются в *ops.
+// perm может содержать лишь часть работ (частичное расписание).
+func (d *hybridDecoder) decode(perm []int, ops *[]Operation) int {
+	inst := d.inst
+	for i := range d.free {
+		d.free[i] = 0
+	}
+	for pos, job := range perm {
+		d.ready[job] = 0
+		d.rank[job] = pos
+	}
+	d.order.jobs = d.order.jobs[:len(perm)]
+	copy(d.order.jobs, perm)
+
+	makespan := 0
+	for s := 0; s < inst.Machines; s++ {
+		if s > 0 {
+			sort.Sort(&d.order)
+		}
+		machines := d.free[d.offset[s]:d.offset[s+1]]
+		for _, job := range d.order.jobs {
+			p := inst.Time(job, s)
+			k := d.pickMachine(machines, d.ready[job], p)
+
+			startAt := d.ready[job]
+			if machines[k] > startAt {
+				startAt = machines[k]
+			}
+			end := startAt + p
+			machines[k] = end
+			d.ready[job] = end
+
+			if ops != nil {
+				*ops = append(*ops, Operation{Job: job, Stage: s, Machine: k, Start: startAt, End: end})
+			}
+			if end > makespan {
+				makespan = end
+			}
+		}
+	}
+	return makespan
+}
+
+// pickMachine выбирает станок стадии для операции, готовой к запуску в момент ready.
+func (d *hybridDecoder) pickMachine(machines []int, ready, p int) int {
+	best := 0
+	if d.inst.Assignment == AssignEarliestCompletion {
+		bestEnd := max(machines[0], ready) + p
+		for k := 1; k < len(machines); k++ {
+			end := max(machines[k], ready) + p
+			// При равном завершении выбираем станок, освободившийся позже (меньше простой)
+			if end < bestEnd || (end == bestEnd && machines[k] > machines[best]) {
+				best, bestEnd = k, end
+			}
+		}
+		return best
+	}
+	for k := 1; k < len(machines); k++ {
+		if machines[k] < machines[best] {
+			best = k
+		}
+	}
+	return best
+}
+
+// readyOrder упорядочивает работы по готовности, при равенстве — по позиции в перестановке.
+type readyOrder struct {
+	jobs  []int
+	ready []int
+	rank  []int
+}
+
+func (o *readyOrder) Len() int { return len(o.jobs) }
+
+func (o *readyOrder) Less(i, j int) bool {
+	a, b := o.jobs[i], o.jobs[j]
+	if o.ready[a] != o.ready[b] {
+		return o.ready[a] < o.ready[b]
+	}
+	return o.rank[a] < o.rank[b]
+}
+
+func (o *readyOrder) Swap(i, j int) { o.jobs[i], o.jobs[j] = o.jobs[j], o.jobs[i] }
+
+// RandomHybridInstance генерирует случайный экземпляр гибридного flow-shop
+// с числом станков на стадии из [1, maxParallel].
+func RandomHybridInstance(jobs, stages, maxParallel, minTime, maxTime int, rng *rand.Rand) *Instance {
+	if maxParallel <= 0 {
+		panic("invalid maxParallel")
+	}
+	inst := RandomInstance(jobs, stages, minTime, maxTime, rng)
+	inst.StageMachines = make([]int, stages)
+	for s := range inst.StageMachines {
+		inst.StageMachines[s] = 1 + rng.Intn(maxParallel)
+	}
+	return inst
+}
@@ -6,11 +6,34 @@ import (
 	"math/rand"
 )
 
+// AssignmentRule — правило выбора станка внутри стадии гибридного flow-shop.
+type AssignmentRule string
+
+const (
+	// AssignEarliestAvailable назначает операцию на станок, освободившийся раньше всех
+	// (при равенстве — станок с меньшим номером).
+	AssignEarliestAvailable AssignmentRule = "earliest-available"
+	// AssignEarliestCompletion назначает операцию на станок с минимальным временем завершения;
+	// при равенстве выбирается станок, освободившийся позже всех (минимальный простой),
+	// затем — станок с меньшим номером.
+	AssignEarliestCompletion AssignmentRule = "earliest-completion"
+)
+
 type Instance struct {
 	Jobs     int
 	Machines int
 	// ProcTimes length must be Jobs*Machines.
 	ProcTimes []int
+
+	// StageMachines — число параллельных идентичных станков на каждой стадии.
+	// nil означает классический PFSP (по одному станку на стадию); иначе длина
+	// должна быть равна Machines, и Machines трактуется как число стадий.
+	StageMachines []int
+	// Assignment — правило назначения операций на станки стадии ("" = earliest-available).
+	Assignment AssignmentRule
+
+	// DueDates — директивные сроки работ (nil, если не заданы); нужны для критерия tardiness.
+	DueDates []int
 }
 
 func NewInstance(jobs, machines int, procTimes []int) (*Instance, error) {
@@ -21,6 +44,21 @@ func NewInstance(jobs, machines int, procTimes []int) (*Instance, error) {
 	return inst, nil
 }
 
+// NewHybridInstance создаёт экземпляр гибридного flow-shop: stages стадий,
+// на стадии s работают stageMachines[s] идентичных параллельных станков.
+func NewHybridInstance(jobs, stages int, stageMachines []int, procTimes []int) (*Instance, error) {
+	inst := &Instance{
+		Jobs:          jobs,
+		Machines:      stages,
+		ProcTimes:     procTimes,
+		StageMachines: stageMachines,
+	}
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst, nil
+}
+
 func (inst *Instance) Validate() error {
 	if inst == nil {
 		return errors.New("instance is nil")
@@ -39,6 +77,32 @@ func (inst *Instance) Validate() error {
 			return fmt.Errorf("procTimes[%d] must be >= 0 (got %d)", i, v)
 		}
 	}
+	if inst.StageMachines != nil {
+		if len(inst.StageMachines) != inst.Machines {
+			return fmt.Errorf("stageMachines length must be %d (got %d)", inst.Machines, len(inst.StageMachines))
+		}
+		for s, k := range inst.StageMachines {
+			if k <= 0 {
+				return fmt.Errorf("stageMachines[%d] must be > 0 (got %d)", s, k)
+			}
+		}
+	}
+	if inst.DueDates != nil {
+		if len(inst.DueDates) != inst.Jobs {
+			return fmt.Errorf("dueDates length must be %d (got %d)", inst.Jobs, len(inst.DueDates))
+		}
+		for j, d := range inst.DueDates {
+			if d < 0 {
+				return fmt.Errorf("dueDates[%d] must be >= 0 (got %d)", j, d)
+			}
+		}
+	}
+	switch inst.Assignment {
+	case "", AssignEarliestAvailable, AssignEarliestCompletion:
+		// ok
+	default:
+		return fmt.Errorf("unknown assignment rule %q", inst.Assignment)
+	}
 	return nil
 }
 
@@ -46,6 +110,24 @@ func (inst *Instance) Time(job, machine int) int {
 	return inst.ProcTimes[job*inst.Machines+machine]
 }
 
+// StageSize возвращает число параллельных станков на стадии stage.
+func (inst *Instance) StageSize(stage int) int {
+	if inst.StageMachines == nil {
+		return 1
+	}
+	return inst.StageMachines[stage]
+}
+
+// IsHybrid сообщает, есть ли хотя бы одна стадия с несколькими станками.
+func (inst *Instance) IsHybrid() bool {
+	for _, k := range inst.StageMachines {
+		if k > 1 {
+			return true
+		}
+	}
+	return false
+}
+
 func RandomInstance(jobs, machines, minTime, maxTime int, rng *rand.Rand) *Instance {
 	if rng == nil {
 		panic("генератор случайных чисел не инициализирован (nil)")
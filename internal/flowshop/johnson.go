@@ -0,0 +1,38 @@
+package flowshop
+
+import "sort"
+
+// JohnsonOrder возвращает оптимальный порядок работ для задачи F2||Cmax
+// по правилу Джонсона (1954): сначала работы с a_j < b_j по возрастанию a_j,
+// затем работы с a_j >= b_j по убыванию b_j. При равенстве ключей работы
+// идут в порядке возрастания номера.
+func JohnsonOrder(a, b []int) []int {
+	n := len(a)
+	first := make([]int, 0, n)
+	second := make([]int, 0, n)
+	for j := 0; j < n; j++ {
+		if a[j] < b[j] {
+			first = append(first, j)
+		} else {
+			second = append(second, j)
+		}
+	}
+	sort.SliceStable(first, func(i, k int) bool { return a[first[i]] < a[first[k]] })
+	sort.SliceStable(second, func(i, k int) bool { return b[second[i]] > b[second[k]] })
+	return append(first, second...)
+}
+
+// TwoMachineMakespan вычисляет makespan последовательности order в задаче
+// двух станков с временами a, b и минимальными задержками lag между станками.
+func TwoMachineMakespan(order, a, b, lag []int) int {
+	c1, c2 := 0, 0
+	for _, j := range order {
+		c1 += a[j]
+		ready := c1
+		if lag != nil {
+			ready += lag[j]
+		}
+		c2 = max(c2, ready) + b[j]
+	}
+	return c2
+}
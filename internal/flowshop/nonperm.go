@@ -0,0 +1,75 @@
+package flowshop
+
+import "fmt"
+
+// ValidateSequences проверяет представление неперестановочного flow-shop:
+// для каждого из m станков — своя перестановка n работ.
+func ValidateSequences(seqs [][]int, n, m int) error {
+	if len(seqs) != m {
+		return fmt.Errorf("sequences count must be %d (got %d)", m, len(seqs))
+	}
+	for k, seq := range seqs {
+		if err := ValidatePermutation(seq, n); err != nil {
+			return fmt.Errorf("machine %d: %w", k, err)
+		}
+	}
+	return nil
+}
+
+// PermutationSequences раскладывает перестановку в одинаковые последовательности для всех m станков.
+func PermutationSequences(perm []int, m int) [][]int {
+	n := len(perm)
+	backing := make([]int, n*m)
+	seqs := make([][]int, m)
+	for k := range seqs {
+		seqs[k] = backing[k*n : (k+1)*n]
+		copy(seqs[k], perm)
+	}
+	return seqs
+}
+
+// SequencesMakespan вычисляет makespan неперестановочного расписания,
+// в котором порядок работ на каждом станке задаётся отдельно.
+// Любой набор последовательностей допустим: дуги технологического порядка
+// ведут только к станкам с большим номером, поэтому циклов не возникает.
+// Поддерживается только классический flow-shop (по одному станку на стадии).
+func (e *Evaluator) SequencesMakespan(seqs [][]int) (int, error) {
+	if e == nil || e.inst == nil {
+		return 0, fmt.Errorf("nil evaluator")
+	}
+	if e.hybrid != nil {
+		return 0, fmt.Errorf("non-permutation sequences are not supported for hybrid flow shop")
+	}
+	if err := ValidateSequences(seqs, e.inst.Jobs, e.inst.Machines); err != nil {
+		return 0, err
+	}
+	if len(e.jobCompletion) != e.inst.Jobs {
+		e.jobCompletion = make([]int, e.inst.Jobs)
+	}
+
+	// jobCompletion[j] — завершение работы j на предыдущем станке
+	done := e.jobCompletion
+	for j := range done {
+		done[j] = 0
+	}
+	t := 0
+	for m, seq := range seqs {
+		t = 0
+		for _, job := range seq {
+			if done[job] > t {
+				t = done[job]
+			}
+			t += e.inst.Time(job, m)
+			done[job] = t
+		}
+	}
+	return t, nil
+}
+
+func (e *Evaluator) MustSequencesMakespan(seqs [][]int) int {
+	ms, err := e.SequencesMakespan(seqs)
+	if err != nil {
+		panic(err)
+	}
+	return ms
+}
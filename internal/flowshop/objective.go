@@ -0,0 +1,161 @@
+package flowshop
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Objective — критерий оптимизации расписания (все критерии минимизируются).
+type Objective string
+
+const (
+	// ObjectiveMakespan — время завершения последней работы.
+	ObjectiveMakespan Objective = "makespan"
+	// ObjectiveFlowTime — суммарное время завершения работ (total flow time).
+	ObjectiveFlowTime Objective = "flowtime"
+	// ObjectiveTardiness — суммарное запаздывание относительно DueDates.
+	ObjectiveTardiness Objective = "tardiness"
+)
+
+// ValidateObjectives проверяет список критериев для данного экземпляра.
+func ValidateObjectives(inst *Instance, objs []Objective) error {
+	if len(objs) == 0 {
+		return fmt.Errorf("objectives list is empty")
+	}
+	seen := make(map[Objective]bool, len(objs))
+	for _, o := range objs {
+		switch o {
+		case ObjectiveMakespan, ObjectiveFlowTime:
+			// ok
+		case ObjectiveTardiness:
+			if inst.DueDates == nil {
+				return fmt.Errorf("objective %q requires due dates", o)
+			}
+		default:
+			return fmt.Errorf("unknown objective %q", o)
+		}
+		if seen[o] {
+			return fmt.Errorf("duplicate objective %q", o)
+		}
+		seen[o] = true
+	}
+	return nil
+}
+
+// Objectives вычисляет значения критериев objs для перестановки и записывает их в out.
+// Список objs проверяется один раз вызывающей стороной (ValidateObjectives); здесь
+// отклоняются только неизвестные критерии, чтобы оценка не выделяла память на каждый вызов.
+func (e *Evaluator) Objectives(perm []int, objs []Objective, out []int) error {
+	if e == nil || e.inst == nil {
+		return fmt.Errorf("nil evaluator")
+	}
+	if len(out) < len(objs) {
+		return fmt.Errorf("objectives output length must be >= %d (got %d)", len(objs), len(out))
+	}
+	if len(e.seen) != e.inst.Jobs {
+		e.seen = make([]bool, e.inst.Jobs)
+	}
+	if err := validatePermutationSeen(perm, e.seen); err != nil {
+		return err
+	}
+
+	done := e.completionTimes(perm)
+	makespan, flow, tardiness := 0, 0, 0
+	for j, c := range done {
+		if c > makespan {
+			makespan = c
+		}
+		flow += c
+		if e.inst.DueDates != nil && c > e.inst.DueDates[j] {
+			tardiness += c - e.inst.DueDates[j]
+		}
+	}
+	for i, o := range objs {
+		switch o {
+		case ObjectiveMakespan:
+			out[i] = makespan
+		case ObjectiveFlowTime:
+			out[i] = flow
+		case ObjectiveTardiness:
+			if e.inst.DueDates == nil {
+				return fmt.Errorf("objective %q requires due dates", o)
+			}
+			out[i] = tardiness
+		default:
+			return fmt.Errorf("unknown objective %q", o)
+		}
+	}
+	return nil
+}
+
+func (e *Evaluator) MustObjectives(perm []int, objs []Objective, out []int) {
+	if err := e.Objectives(perm, objs, out); err != nil {
+		panic(err)
+	}
+}
+
+// completionTimes возвращает время завершения каждой работы на последней стадии.
+// Результат — внутренний буфер оценщика, действителен до следующего вызова.
+func (e *Evaluator) completionTimes(perm []int) []int {
+	if e.hybrid != nil {
+		e.hybrid.decode(perm, nil)
+		return e.hybrid.ready
+	}
+	if len(e.jobCompletion) != e.inst.Jobs {
+		e.jobCompletion = make([]int, e.inst.Jobs)
+	}
+	for m := range e.machineCompletion {
+		e.machineCompletion[m] = 0
+	}
+	for _, job := range perm {
+		e.machineCompletion[0] += e.inst.Time(job, 0)
+		for m := 1; m < e.inst.Machines; m++ {
+			left := e.machineCompletion[m-1]
+			up := e.machineCompletion[m]
+			if left > up {
+				e.machineCompletion[m] = left + e.inst.Time(job, m)
+			} else {
+				e.machineCompletion[m] = up + e.inst.Time(job, m)
+			}
+		}
+		e.jobCompletion[job] = e.machineCompletion[e.inst.Machines-1]
+	}
+	return e.jobCompletion
+}
+
+// RandomDueDates генерирует директивные сроки по схеме Поттса—Ван Вассенхова:
+// d_j ~ U[P(1−T−R/2), P(1−T+R/2)], где P — максимальная загрузка станка (стадии),
+// T — коэффициент запаздывания, R — относительный разброс сроков.
+// Срок не может быть меньше суммарного времени обработки работы.
+func RandomDueDates(inst *Instance, tardinessFactor, dueRange float64, rng *rand.Rand) []int {
+	if rng == nil {
+		panic("генератор случайных чисел не инициализирован (nil)")
+	}
+	load := 0
+	for m := 0; m < inst.Machines; m++ {
+		sum := 0
+		for j := 0; j < inst.Jobs; j++ {
+			sum += inst.Time(j, m)
+		}
+		sum = (sum + inst.StageSize(m) - 1) / inst.StageSize(m)
+		if sum > load {
+			load = sum
+		}
+	}
+
+	lo := float64(load) * (1 - tardinessFactor - dueRange/2)
+	hi := float64(load) * (1 - tardinessFactor + dueRange/2)
+	due := make([]int, inst.Jobs)
+	for j := range due {
+		d := int(lo + rng.Float64()*(hi-lo))
+		total := 0
+		for m := 0; m < inst.Machines; m++ {
+			total += inst.Time(j, m)
+		}
+		if d < total {
+			d = total
+		}
+		due[j] = d
+	}
+	return due
+}
@@ -6,7 +6,16 @@ func ValidatePermutation(perm []int, n int) error {
 	if len(perm) != n {
 		return fmt.Errorf("permutation length must be %d (got %d)", n, len(perm))
 	}
-	seen := make([]bool, n)
+	return validatePermutationSeen(perm, make([]bool, n))
+}
+
+// validatePermutationSeen проверяет перестановку с внешним буфером отметок seen длины n.
+func validatePermutationSeen(perm []int, seen []bool) error {
+	n := len(seen)
+	if len(perm) != n {
+		return fmt.Errorf("permutation length must be %d (got %d)", n, len(perm))
+	}
+	clear(seen)
 	for i, v := range perm {
 		if v < 0 || v >= n {
 			return fmt.Errorf("perm[%d]=%d out of range [0,%d)", i, v, n)
@@ -0,0 +1,278 @@
+package ga
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Adaptation — способ адаптации операторов и вероятностей GA в ходе поиска.
+type Adaptation string
+
+const (
+	// AdaptationFixed — постоянные CrossoverRate, MutationRate и операторы.
+	AdaptationFixed Adaptation = "fixed"
+	// AdaptationSrinivasPatnaik — адаптивные вероятности Шриниваса–Патнаика (1994):
+	// пары и особи лучше среднего скрещиваются и мутируют реже, пропорционально
+	// близости к лучшей особи; CrossoverRate и MutationRate задают максимальные значения.
+	AdaptationSrinivasPatnaik Adaptation = "srinivas-patnaik"
+	// AdaptationPursuit — адаптивное преследование (Thierens, 2005):
+	// вероятности выбора операторов смещаются к оператору с наибольшим качеством.
+	AdaptationPursuit Adaptation = "pursuit"
+	// AdaptationBandit — многорукий бандит UCB1 над набором операторов.
+	AdaptationBandit Adaptation = "bandit"
+)
+
+// Для pursuit и bandit награда оператора равна 1, если потомок (до локального спуска)
+// строго лучше лучшего из родителей, и 0 иначе. Кроссовер и мутация, применённые
+// к потомку, получают одну и ту же награду.
+
+// operatorPool — статистика и правило выбора для набора операторов одного вида.
+type operatorPool struct {
+	method Adaptation
+	names  []string
+
+	// uses — число применений, rewards — число начисленных наград, successes — успешных
+	uses, rewards, successes []int
+	total                    int
+
+	quality []float64 // pursuit: экспоненциальное среднее; bandit: среднее награды
+	prob    []float64 // pursuit: вероятности выбора
+
+	rate, minProb, exploration float64
+}
+
+func newOperatorPool(cfg Config, names []string) *operatorPool {
+	k := len(names)
+	p := &operatorPool{
+		method:      cfg.Adaptation,
+		names:       names,
+		uses:        make([]int, k),
+		rewards:     make([]int, k),
+		successes:   make([]int, k),
+		quality:     make([]float64, k),
+		prob:        make([]float64, k),
+		rate:        cfg.AdaptRate,
+		minProb:     cfg.AdaptMinProb,
+		exploration: cfg.AdaptExploration,
+	}
+	for i := range p.prob {
+		p.prob[i] = 1 / float64(k)
+	}
+	return p
+}
+
+// choose выбирает оператор и учитывает его применение.
+func (p *operatorPool) choose(rng *rand.Rand) int {
+	i := 0
+	switch p.method {
+	case AdaptationPursuit:
+		r := rng.Float64()
+		acc := 0.0
+		i = len(p.prob) - 1
+		for j, v := range p.prob {
+			acc += v
+			if r < acc {
+				i = j
+				break
+			}
+		}
+	case AdaptationBandit:
+		i = p.ucb()
+	}
+	p.uses[i]++
+	p.total++
+	return i
+}
+
+// ucb выбирает оператор по правилу UCB1; каждый оператор сначала пробуется один раз.
+func (p *operatorPool) ucb() int {
+	for i, n := range p.rewards {
+		if n == 0 {
+			return i
+		}
+	}
+	best, bestValue := 0, math.Inf(-1)
+	logTotal := math.Log(float64(p.total))
+	for i, q := range p.quality {
+		v := q + p.exploration*math.Sqrt(2*logTotal/float64(p.rewards[i]))
+		if v > bestValue {
+			best, bestValue = i, v
+		}
+	}
+	return best
+}
+
+// reward начисляет награду r оператору i.
+func (p *operatorPool) reward(i int, r float64) {
+	p.rewards[i]++
+	if r > 0 {
+		p.successes[i]++
+	}
+	switch p.method {
+	case AdaptationPursuit:
+		p.quality[i] += p.rate * (r - p.quality[i])
+
+		best := 0
+		for j, q := range p.quality {
+			if q > p.quality[best] {
+				best = j
+			}
+		}
+		maxProb := 1 - float64(len(p.prob)-1)*p.minProb
+		for j := range p.prob {
+			target := p.minProb
+			if j == best {
+				target = maxProb
+			}
+			p.prob[j] += p.rate * (target - p.prob[j])
+		}
+	case AdaptationBandit:
+		p.quality[i] += (r - p.quality[i]) / float64(p.rewards[i])
+	}
+}
+
+// meta записывает статистику операторов с префиксом prefix.
+func (p *operatorPool) meta(prefix string, meta map[string]any) {
+	for i, name := range p.names {
+		key := prefix + "_" + name
+		meta[key+"_uses"] = p.uses[i]
+		meta[key+"_successes"] = p.successes[i]
+		switch p.method {
+		case AdaptationPursuit:
+			meta[key+"_prob"] = p.prob[i]
+		case AdaptationBandit:
+			meta[key+"_reward"] = p.quality[i]
+		}
+	}
+}
+
+// adaptation — состояние адаптивного режима одного запуска GA.
+type adaptation struct {
+	method Adaptation
+
+	// Наборы операторов (nil — оператор фиксирован)
+	crossovers []crossoverFunc
+	mutations  []func(p []int, rng *rand.Rand)
+	xoPool     *operatorPool
+	mutPool    *operatorPool
+
+	// Операторы последней пары потомков (-1 — не применялся) и лучший из родителей
+	lastXO     int
+	lastMut    [2]int
+	parentBest int
+
+	// Статистика Шриниваса–Патнаика
+	pcSum, pmSum     float64
+	pcCount, pmCount int
+}
+
+// newAdaptation возвращает nil для постоянных параметров.
+func newAdaptation(cfg Config) *adaptation {
+	a := &adaptation{method: cfg.Adaptation}
+	switch cfg.Adaptation {
+	case AdaptationFixed:
+		return nil
+	case AdaptationPursuit, AdaptationBandit:
+		if len(cfg.AdaptiveCrossovers) > 0 {
+			names := make([]string, len(cfg.AdaptiveCrossovers))
+			for i, op := range cfg.AdaptiveCrossovers {
+				a.crossovers = append(a.crossovers, crossoverOperator(op))
+				names[i] = string(op)
+			}
+			a.xoPool = newOperatorPool(cfg, names)
+		}
+		if len(cfg.AdaptiveMutations) > 0 {
+			names := make([]string, len(cfg.AdaptiveMutations))
+			for i, op := range cfg.AdaptiveMutations {
+				a.mutations = append(a.mutations, mutationOperator(op, cfg.MutationSwaps))
+				names[i] = string(op)
+			}
+			a.mutPool = newOperatorPool(cfg, names)
+		}
+	}
+	return a
+}
+
+// begin запоминает родителей новой пары и возвращает вероятности кроссовера
+// и мутации первого и второго потомка.
+func (a *adaptation) begin(cfg Config, scores []int, p1, p2 int) (pc, pm1, pm2 float64) {
+	a.lastXO = -1
+	a.lastMut = [2]int{-1, -1}
+	a.parentBest = min(scores[p1], scores[p2])
+
+	pc, pm1, pm2 = cfg.CrossoverRate, cfg.MutationRate, cfg.MutationRate
+	if a.method != AdaptationSrinivasPatnaik {
+		return pc, pm1, pm2
+	}
+
+	best, sum := scores[0], 0
+	for _, v := range scores {
+		best = min(best, v)
+		sum += v
+	}
+	avg := float64(sum) / float64(len(scores))
+
+	// Для минимизации: k·(C − Cmin)/(Cavg − Cmin) для особей лучше среднего, k — для остальных
+	scale := func(k float64, c int) float64 {
+		if float64(c) > avg || avg <= float64(best) {
+			return k
+		}
+		return k * (float64(c) - float64(best)) / (avg - float64(best))
+	}
+	pc = scale(cfg.CrossoverRate, a.parentBest)
+	pm1 = scale(cfg.MutationRate, scores[p1])
+	pm2 = scale(cfg.MutationRate, scores[p2])
+
+	a.pcSum += pc
+	a.pcCount++
+	a.pmSum += pm1 + pm2
+	a.pmCount += 2
+	return pc, pm1, pm2
+}
+
+// crossover возвращает оператор кроссовера для текущей пары.
+func (a *adaptation) crossover(def crossoverFunc, rng *rand.Rand) crossoverFunc {
+	if a.xoPool == nil {
+		return def
+	}
+	a.lastXO = a.xoPool.choose(rng)
+	return a.crossovers[a.lastXO]
+}
+
+// mutation возвращает оператор мутации для потомка k (0 или 1).
+func (a *adaptation) mutation(def func(p []int, rng *rand.Rand), k int, rng *rand.Rand) func(p []int, rng *rand.Rand) {
+	if a.mutPool == nil {
+		return def
+	}
+	a.lastMut[k] = a.mutPool.choose(rng)
+	return a.mutations[a.lastMut[k]]
+}
+
+// credit начисляет награду операторам, породившим потомка k с makespan ms.
+func (a *adaptation) credit(k, ms int) {
+	r := 0.0
+	if ms < a.parentBest {
+		r = 1
+	}
+	if a.lastXO >= 0 {
+		a.xoPool.reward(a.lastXO, r)
+	}
+	if a.lastMut[k] >= 0 {
+		a.mutPool.reward(a.lastMut[k], r)
+	}
+}
+
+// meta дополняет Meta статистикой адаптации.
+func (a *adaptation) meta(meta map[string]any) {
+	meta["adaptation"] = string(a.method)
+	if a.pcCount > 0 {
+		meta["mean_crossover_rate"] = a.pcSum / float64(a.pcCount)
+		meta["mean_mutation_rate"] = a.pmSum / float64(a.pmCount)
+	}
+	if a.xoPool != nil {
+		a.xoPool.meta("crossover", meta)
+	}
+	if a.mutPool != nil {
+		a.mutPool.meta("mutation", meta)
+	}
+}
@@ -1,6 +1,33 @@
 package ga
 
-import "fmt"
+import (
+	"fmt"
+
+	"flowShop/internal/flowshop"
+	"flowShop/internal/ls"
+)
+
+// LocalSearchScope определяет, к каким особям применяется локальный поиск.
+type LocalSearchScope string
+
+const (
+	// LocalSearchAll — к каждому потомку.
+	LocalSearchAll LocalSearchScope = "all"
+	// LocalSearchFraction — к потомку с вероятностью LocalSearchRate.
+	LocalSearchFraction LocalSearchScope = "fraction"
+	// LocalSearchElite — только к элитным особям нового поколения.
+	LocalSearchElite LocalSearchScope = "elite"
+)
+
+// LocalSearchMode определяет, как результат локального поиска возвращается в популяцию.
+type LocalSearchMode string
+
+const (
+	// LocalSearchLamarckian — улучшенная перестановка заменяет генотип особи.
+	LocalSearchLamarckian LocalSearchMode = "lamarckian"
+	// LocalSearchBaldwinian — генотип не меняется, особь получает приспособленность улучшенного решения.
+	LocalSearchBaldwinian LocalSearchMode = "baldwinian"
+)
 
 type Config struct {
 	Population     int
@@ -9,6 +36,53 @@ type Config struct {
 	TournamentSize int
 	CrossoverRate  float64
 	MutationRate   float64
+
+	// Crossover — оператор рекомбинации
+	Crossover Crossover
+
+	// Mutation — оператор мутации; MutationSwaps — число обменов для multi-swap
+	Mutation      Mutation
+	MutationSwaps int
+
+	// Selection — схема отбора родителей; TruncationRate — доля лучших для truncation
+	Selection      Selection
+	TruncationRate float64
+
+	// Replacement — политика замены поколений
+	// (Elite используется только поколенческой заменой)
+	Replacement Replacement
+
+	// EliminateDuplicates — заменять повторные вхождения особей случайными перестановками
+	EliminateDuplicates bool
+	// RestartDiversity — порог средней доли позиций, отличающихся от лучшей особи,
+	// ниже которого популяция считается сошедшейся и перезапускается (0 — без перезапусков)
+	RestartDiversity float64
+	// RestartKeep — число лучших особей, сохраняемых при перезапуске
+	RestartKeep int
+
+	// LocalSearch — локальный спуск меметического варианта;
+	// нулевое значение отключает гибридизацию
+	LocalSearch ls.Config
+
+	// LocalSearchScope — к каким особям применяется спуск
+	LocalSearchScope LocalSearchScope
+	// LocalSearchRate — вероятность спуска для потомка (для области fraction)
+	LocalSearchRate float64
+	// LocalSearchMode — ламарковская или болдуиновская запись результата
+	LocalSearchMode LocalSearchMode
+
+	// Adaptation — адаптация вероятностей или выбора операторов в ходе поиска
+	Adaptation Adaptation
+	// AdaptiveCrossovers, AdaptiveMutations — наборы операторов для pursuit и bandit
+	// (пустой набор — используется фиксированный Crossover или Mutation)
+	AdaptiveCrossovers []Crossover
+	AdaptiveMutations  []Mutation
+	// AdaptRate — скорость обучения адаптивного преследования
+	AdaptRate float64
+	// AdaptMinProb — минимальная вероятность выбора оператора при преследовании
+	AdaptMinProb float64
+	// AdaptExploration — коэффициент исследования UCB1
+	AdaptExploration float64
 }
 
 func (c Config) Validate() error {
@@ -48,6 +122,129 @@ func (c Config) Validate() error {
 			c.MutationRate,
 		)
 	}
+	if crossoverOperator(c.Crossover) == nil {
+		return fmt.Errorf("неизвестный оператор кроссовера %q", c.Crossover)
+	}
+	if mutationOperator(c.Mutation, c.MutationSwaps) == nil {
+		return fmt.Errorf("неизвестный оператор мутации %q", c.Mutation)
+	}
+	if c.Mutation == MutationMultiSwap && c.MutationSwaps <= 0 {
+		return fmt.Errorf(
+			"число обменов мутации multi-swap должно быть > 0 (получено %d)",
+			c.MutationSwaps,
+		)
+	}
+	if !validSelection(c.Selection) {
+		return fmt.Errorf("неизвестная схема отбора %q", c.Selection)
+	}
+	if c.Selection == SelectionTruncation && (c.TruncationRate <= 0 || c.TruncationRate > 1) {
+		return fmt.Errorf(
+			"доля отбора усечением должна быть в диапазоне (0,1] (получено %f)",
+			c.TruncationRate,
+		)
+	}
+	if !validReplacement(c.Replacement) {
+		return fmt.Errorf("неизвестная политика замены %q", c.Replacement)
+	}
+	if c.RestartDiversity < 0 || c.RestartDiversity >= 1 {
+		return fmt.Errorf(
+			"порог разнообразия для перезапуска должен быть в диапазоне [0,1) (получено %f)",
+			c.RestartDiversity,
+		)
+	}
+	if c.RestartDiversity > 0 && (c.RestartKeep <= 0 || c.RestartKeep >= c.Population) {
+		return fmt.Errorf(
+			"число сохраняемых при перезапуске особей должно быть в диапазоне [1, population) (получено %d)",
+			c.RestartKeep,
+		)
+	}
+	if c.LocalSearch.Enabled() {
+		if err := c.LocalSearch.Validate(); err != nil {
+			return err
+		}
+		switch c.LocalSearchScope {
+		case LocalSearchAll:
+			// ok
+		case LocalSearchFraction:
+			if c.LocalSearchRate <= 0 || c.LocalSearchRate > 1 {
+				return fmt.Errorf(
+					"доля потомков для локального поиска должна быть в диапазоне (0,1] (получено %f)",
+					c.LocalSearchRate,
+				)
+			}
+		case LocalSearchElite:
+			if c.Elite == 0 {
+				return fmt.Errorf("для локального поиска по элите требуется Elite > 0")
+			}
+		default:
+			return fmt.Errorf(
+				"неизвестная область применения локального поиска %q",
+				c.LocalSearchScope,
+			)
+		}
+		switch c.LocalSearchMode {
+		case LocalSearchLamarckian, LocalSearchBaldwinian:
+			// ok
+		default:
+			return fmt.Errorf(
+				"неизвестный режим записи локального поиска %q",
+				c.LocalSearchMode,
+			)
+		}
+	}
+	return c.validateAdaptation()
+}
+
+func (c Config) validateAdaptation() error {
+	switch c.Adaptation {
+	case AdaptationFixed, AdaptationSrinivasPatnaik:
+		return nil
+	case AdaptationPursuit, AdaptationBandit:
+		// ok
+	default:
+		return fmt.Errorf("неизвестный режим адаптации %q", c.Adaptation)
+	}
+
+	if len(c.AdaptiveCrossovers) == 0 && len(c.AdaptiveMutations) == 0 {
+		return fmt.Errorf("для адаптивного выбора операторов требуется хотя бы один набор операторов")
+	}
+	for _, op := range c.AdaptiveCrossovers {
+		if crossoverOperator(op) == nil {
+			return fmt.Errorf("неизвестный оператор кроссовера %q", op)
+		}
+	}
+	for _, op := range c.AdaptiveMutations {
+		if mutationOperator(op, c.MutationSwaps) == nil {
+			return fmt.Errorf("неизвестный оператор мутации %q", op)
+		}
+		if op == MutationMultiSwap && c.MutationSwaps <= 0 {
+			return fmt.Errorf(
+				"число обменов мутации multi-swap должно быть > 0 (получено %d)",
+				c.MutationSwaps,
+			)
+		}
+	}
+	if c.AdaptRate <= 0 || c.AdaptRate > 1 {
+		return fmt.Errorf(
+			"скорость адаптации должна быть в диапазоне (0,1] (получено %f)",
+			c.AdaptRate,
+		)
+	}
+	for _, k := range []int{len(c.AdaptiveCrossovers), len(c.AdaptiveMutations)} {
+		if k > 0 && (c.AdaptMinProb < 0 || c.AdaptMinProb*float64(k) >= 1) {
+			return fmt.Errorf(
+				"минимальная вероятность оператора должна быть в диапазоне [0, 1/%d) (получено %f)",
+				k,
+				c.AdaptMinProb,
+			)
+		}
+	}
+	if c.AdaptExploration < 0 {
+		return fmt.Errorf(
+			"коэффициент исследования должен быть >= 0 (получено %f)",
+			c.AdaptExploration,
+		)
+	}
 	return nil
 }
 
@@ -59,5 +256,190 @@ func DefaultConfig() Config {
 		TournamentSize: 5,
 		CrossoverRate:  0.90,
 		MutationRate:   0.15,
+		Crossover:      CrossoverOX,
+		Mutation:       MutationSwap,
+		MutationSwaps:  3,
+		Selection:      SelectionTournament,
+		TruncationRate: 0.5,
+		Replacement:    ReplacementGenerational,
+		RestartKeep:    4,
+
+		LocalSearchScope: LocalSearchAll,
+		LocalSearchRate:  0.1,
+		LocalSearchMode:  LocalSearchLamarckian,
+
+		Adaptation: AdaptationFixed,
+		AdaptiveCrossovers: []Crossover{
+			CrossoverOX, CrossoverPMX, CrossoverTwoPoint,
+			CrossoverSJOX, CrossoverSBOX, CrossoverSB2OX,
+		},
+		AdaptiveMutations: []Mutation{
+			MutationSwap, MutationInsert, MutationInversion, MutationScramble,
+		},
+		AdaptRate:        0.3,
+		AdaptMinProb:     0.05,
+		AdaptExploration: 1.0,
+	}
+}
+
+// NSGA2Config — параметры многокритериального алгоритма NSGA-II.
+type NSGA2Config struct {
+	Population     int
+	Generations    int
+	TournamentSize int
+	CrossoverRate  float64
+	MutationRate   float64
+
+	// Objectives — минимизируемые критерии (не менее двух)
+	Objectives []flowshop.Objective
+}
+
+func (c NSGA2Config) Validate() error {
+	if c.Population <= 1 {
+		return fmt.Errorf(
+			"размер популяции должен быть > 1 (получено %d)",
+			c.Population,
+		)
+	}
+	if c.Generations <= 0 {
+		return fmt.Errorf(
+			"количество поколений должно быть > 0 (получено %d)",
+			c.Generations,
+		)
+	}
+	if c.TournamentSize <= 0 {
+		return fmt.Errorf(
+			"размер турнира должен быть > 0 (получено %d)",
+			c.TournamentSize,
+		)
+	}
+	if c.CrossoverRate < 0 || c.CrossoverRate > 1 {
+		return fmt.Errorf(
+			"вероятность кроссовера должна быть в диапазоне [0,1] (получено %f)",
+			c.CrossoverRate,
+		)
+	}
+	if c.MutationRate < 0 || c.MutationRate > 1 {
+		return fmt.Errorf(
+			"вероятность мутации должна быть в диапазоне [0,1] (получено %f)",
+			c.MutationRate,
+		)
+	}
+	if len(c.Objectives) < 2 {
+		return fmt.Errorf(
+			"нужно не менее двух критериев (получено %d)",
+			len(c.Objectives),
+		)
+	}
+	return nil
+}
+
+func DefaultNSGA2Config() NSGA2Config {
+	return NSGA2Config{
+		Population:     100,
+		Generations:    300,
+		TournamentSize: 2,
+		CrossoverRate:  0.90,
+		MutationRate:   0.20,
+		Objectives: []flowshop.Objective{
+			flowshop.ObjectiveMakespan,
+			flowshop.ObjectiveFlowTime,
+		},
+	}
+}
+
+// Topology — схема связей островов при миграции.
+type Topology string
+
+const (
+	// TopologyRing — остров i отправляет мигрантов острову (i+1) mod k.
+	TopologyRing Topology = "ring"
+	// TopologyFull — каждый остров отправляет мигрантов всем остальным.
+	TopologyFull Topology = "full"
+)
+
+// MigrantReplacement — какие особи острова-получателя вытесняются мигрантами.
+type MigrantReplacement string
+
+const (
+	// MigrantReplaceWorst — худшие особи.
+	MigrantReplaceWorst MigrantReplacement = "worst"
+	// MigrantReplaceRandom — случайные особи, кроме лучшей.
+	MigrantReplaceRandom MigrantReplacement = "random"
+)
+
+// IslandConfig — параметры островной модели GA.
+type IslandConfig struct {
+	// Islands — число островов (подпопуляций)
+	Islands int
+	// Island — параметры GA на каждом острове; Population — размер одного острова,
+	// Generations — общее число поколений
+	Island Config
+
+	// MigrationInterval — число поколений между миграциями
+	MigrationInterval int
+	// Migrants — число лучших особей, отправляемых каждому соседу
+	Migrants    int
+	Topology    Topology
+	Replacement MigrantReplacement
+}
+
+func (c IslandConfig) Validate() error {
+	if c.Islands <= 1 {
+		return fmt.Errorf(
+			"число островов должно быть > 1 (получено %d)",
+			c.Islands,
+		)
+	}
+	if err := c.Island.Validate(); err != nil {
+		return err
+	}
+	if c.MigrationInterval <= 0 {
+		return fmt.Errorf(
+			"интервал миграции должен быть > 0 (получено %d)",
+			c.MigrationInterval,
+		)
+	}
+	if c.Migrants <= 0 {
+		return fmt.Errorf(
+			"число мигрантов должно быть > 0 (получено %d)",
+			c.Migrants,
+		)
+	}
+	sources := 1
+	switch c.Topology {
+	case TopologyRing:
+		// ok
+	case TopologyFull:
+		sources = c.Islands - 1
+	default:
+		return fmt.Errorf("неизвестная топология миграции %q", c.Topology)
+	}
+	if c.Migrants*sources >= c.Island.Population {
+		return fmt.Errorf(
+			"число прибывающих мигрантов (%d) должно быть меньше размера острова (%d)",
+			c.Migrants*sources,
+			c.Island.Population,
+		)
+	}
+	switch c.Replacement {
+	case MigrantReplaceWorst, MigrantReplaceRandom:
+		// ok
+	default:
+		return fmt.Errorf("неизвестная политика замещения мигрантами %q", c.Replacement)
+	}
+	return nil
+}
+
+func DefaultIslandConfig() IslandConfig {
+	island := DefaultConfig()
+	island.Population = 50
+	return IslandConfig{
+		Islands:           4,
+		Island:            island,
+		MigrationInterval: 20,
+		Migrants:          2,
+		Topology:          TopologyRing,
+		Replacement:       MigrantReplaceWorst,
 	}
 }
@@ -0,0 +1,229 @@
+package ga
+
+import "math/rand"
+
+// Crossover — оператор рекомбинации перестановок.
+type Crossover string
+
+const (
+	// CrossoverOX — order crossover (сегмент первого родителя, остальное — в порядке второго).
+	CrossoverOX Crossover = "ox"
+	// CrossoverPMX — partially mapped crossover (Goldberg, Lingle).
+	CrossoverPMX Crossover = "pmx"
+	// CrossoverCX — cycle crossover (Oliver и др.).
+	CrossoverCX Crossover = "cx"
+	// CrossoverTwoPoint — двухточечный порядковый кроссовер (Murata и др.):
+	// начало и конец наследуются от первого родителя, середина — в порядке второго.
+	CrossoverTwoPoint Crossover = "two-point"
+	// CrossoverSJOX — similar job order crossover (Ruiz и др., 2006).
+	CrossoverSJOX Crossover = "sjox"
+	// CrossoverSBOX — similar block order crossover (Ruiz и др., 2006).
+	CrossoverSBOX Crossover = "sbox"
+	// CrossoverSB2OX — similar block 2-point order crossover (Ruiz и др., 2006).
+	CrossoverSB2OX Crossover = "sb2ox"
+)
+
+// crossoverFunc — общая сигнатура операторов: из родителей p1, p2 строятся потомки c1, c2.
+// mark и stamp — буферы отметки уже включённых работ, общие для всех операторов.
+type crossoverFunc func(p1, p2, c1, c2 []int, rng *rand.Rand, mark []int, stamp *int)
+
+// crossoverOperator возвращает реализацию оператора (nil для неизвестного).
+func crossoverOperator(op Crossover) crossoverFunc {
+	switch op {
+	case CrossoverOX:
+		return orderCrossoverOX
+	case CrossoverPMX:
+		return crossoverPMX
+	case CrossoverCX:
+		return crossoverCX
+	case CrossoverTwoPoint:
+		return crossoverTwoPoint
+	case CrossoverSJOX:
+		return crossoverSJOX
+	case CrossoverSBOX:
+		return crossoverSBOX
+	case CrossoverSB2OX:
+		return crossoverSB2OX
+	}
+	return nil
+}
+
+// randomSegment выбирает случайный непустой отрезок [a, b).
+func randomSegment(n int, rng *rand.Rand) (int, int) {
+	a := rng.Intn(n)
+	b := rng.Intn(n)
+	if a > b {
+		a, b = b, a
+	}
+	if a == b {
+		b = a + 1
+	}
+	return a, b
+}
+
+// crossoverPMX реализует оператор PMX: сегмент [a, b) копируется из прямого родителя,
+// остальные позиции берутся из другого родителя, а конфликтующие работы
+// заменяются по цепочке соответствий сегмента.
+func crossoverPMX(p1, p2, c1, c2 []int, rng *rand.Rand, mark []int, stamp *int) {
+	a, b := randomSegment(len(p1), rng)
+	pmxChild(p1, p2, c1, a, b, mark, stamp)
+	pmxChild(p2, p1, c2, a, b, mark, stamp)
+}
+
+func pmxChild(direct, other, child []int, a, b int, mark []int, stamp *int) {
+	*stamp++
+	curStamp := *stamp
+
+	for i := a; i < b; i++ {
+		child[i] = direct[i]
+		mark[direct[i]] = curStamp
+	}
+	for i := range child {
+		if i >= a && i < b {
+			continue
+		}
+		gene := other[i]
+		// Пока работа уже есть в сегменте, переходим по соответствию direct[k] -> other[k]
+		for mark[gene] == curStamp {
+			k := a
+			for direct[k] != gene {
+				k++
+			}
+			gene = other[k]
+		}
+		child[i] = gene
+		mark[gene] = curStamp
+	}
+}
+
+// crossoverCX реализует оператор CX: позиции цикла, начинающегося с позиции 0,
+// наследуются от прямого родителя, остальные — от другого.
+func crossoverCX(p1, p2, c1, c2 []int, rng *rand.Rand, mark []int, stamp *int) {
+	*stamp++
+	curStamp := *stamp
+
+	// c1 временно хранит обратную перестановку p1: c1[job] — позиция job в p1
+	for i, job := range p1 {
+		c1[job] = i
+	}
+	// Отмечаем позиции цикла
+	for i := 0; mark[i] != curStamp; i = c1[p2[i]] {
+		mark[i] = curStamp
+	}
+
+	for i := range p1 {
+		if mark[i] == curStamp {
+			c1[i], c2[i] = p1[i], p2[i]
+		} else {
+			c1[i], c2[i] = p2[i], p1[i]
+		}
+	}
+}
+
+// crossoverTwoPoint реализует двухточечный порядковый кроссовер:
+// позиции вне [a, b) наследуются от прямого родителя,
+// середина заполняется недостающими работами в порядке другого родителя.
+func crossoverTwoPoint(p1, p2, c1, c2 []int, rng *rand.Rand, mark []int, stamp *int) {
+	a, b := randomSegment(len(p1), rng)
+	twoPointChild(p1, p2, c1, a, b, mark, stamp)
+	twoPointChild(p2, p1, c2, a, b, mark, stamp)
+}
+
+func twoPointChild(direct, other, child []int, a, b int, mark []int, stamp *int) {
+	*stamp++
+	curStamp := *stamp
+
+	for i := range child {
+		if i >= a && i < b {
+			child[i] = -1
+			continue
+		}
+		child[i] = direct[i]
+		mark[direct[i]] = curStamp
+	}
+	fillInOrder(child, other, mark, curStamp)
+}
+
+// crossoverSJOX: работы, стоящие в обоих родителях на одинаковых позициях,
+// сохраняются в обоих потомках; префикс до точки разреза наследуется от прямого
+// родителя, остальное — в порядке другого.
+func crossoverSJOX(p1, p2, c1, c2 []int, rng *rand.Rand, mark []int, stamp *int) {
+	a, b := 0, onePointCut(len(p1), rng)
+	similarChild(p1, p2, c1, a, b, false, mark, stamp)
+	similarChild(p2, p1, c2, a, b, false, mark, stamp)
+}
+
+// crossoverSBOX — как SJOX, но сохраняются только совпадающие блоки
+// не менее чем из двух подряд идущих работ.
+func crossoverSBOX(p1, p2, c1, c2 []int, rng *rand.Rand, mark []int, stamp *int) {
+	a, b := 0, onePointCut(len(p1), rng)
+	similarChild(p1, p2, c1, a, b, true, mark, stamp)
+	similarChild(p2, p1, c2, a, b, true, mark, stamp)
+}
+
+// crossoverSB2OX — как SBOX, но от прямого родителя наследуется отрезок
+// между двумя точками разреза.
+func crossoverSB2OX(p1, p2, c1, c2 []int, rng *rand.Rand, mark []int, stamp *int) {
+	a, b := randomSegment(len(p1), rng)
+	similarChild(p1, p2, c1, a, b, true, mark, stamp)
+	similarChild(p2, p1, c2, a, b, true, mark, stamp)
+}
+
+// onePointCut выбирает точку разреза k в [1, n-1] (для n == 1 — 1).
+func onePointCut(n int, rng *rand.Rand) int {
+	if n < 2 {
+		return n
+	}
+	return 1 + rng.Intn(n-1)
+}
+
+// similarChild строит потомка операторов семейства SJOX:
+// сохраняет совпадающие в обоих родителях позиции (при blocks — только блоки длины >= 2),
+// копирует из прямого родителя отрезок [a, b) и дозаполняет недостающие работы
+// в порядке другого родителя.
+func similarChild(direct, other, child []int, a, b int, blocks bool, mark []int, stamp *int) {
+	*stamp++
+	curStamp := *stamp
+	n := len(child)
+
+	for i := range child {
+		child[i] = -1
+	}
+	for i := 0; i < n; i++ {
+		if direct[i] != other[i] {
+			continue
+		}
+		if blocks {
+			left := i > 0 && direct[i-1] == other[i-1]
+			right := i+1 < n && direct[i+1] == other[i+1]
+			if !left && !right {
+				continue
+			}
+		}
+		child[i] = direct[i]
+		mark[direct[i]] = curStamp
+	}
+	for i := a; i < b; i++ {
+		if child[i] == -1 {
+			child[i] = direct[i]
+			mark[direct[i]] = curStamp
+		}
+	}
+	fillInOrder(child, other, mark, curStamp)
+}
+
+// fillInOrder заполняет свободные (-1) позиции потомка
+// неотмеченными работами в порядке их следования в other.
+func fillInOrder(child, other []int, mark []int, curStamp int) {
+	pos := 0
+	for _, gene := range other {
+		if mark[gene] == curStamp {
+			continue
+		}
+		for child[pos] != -1 {
+			pos++
+		}
+		child[pos] = gene
+		mark[gene] = curStamp
+	}
+}
@@ -0,0 +1,123 @@
+package ga
+
+import "flowShop/internal/ls"
+
+// Diversity — показатели разнообразия популяции относительно её лучшей особи.
+type Diversity struct {
+	// Hamming — средняя доля позиций, в которых особь отличается от лучшей (0..1)
+	Hamming float64
+	// Position — среднее смещение работы относительно её позиции в лучшей особи,
+	// нормированное на число работ (0..1)
+	Position float64
+	// Unique — число различных особей
+	Unique int
+}
+
+// Progress — состояние GA после очередного поколения, передаваемое в Solver.Progress.
+type Progress struct {
+	Generation   int
+	BestMakespan int
+	Diversity    Diversity
+	Restarts     int
+}
+
+// diversityMeter вычисляет показатели разнообразия без выделений памяти в цикле.
+type diversityMeter struct {
+	pos  []int // pos[job] — позиция работы в лучшей особи
+	seen map[uint64]struct{}
+}
+
+func newDiversityMeter(jobs, popSize int) *diversityMeter {
+	return &diversityMeter{
+		pos:  make([]int, jobs),
+		seen: make(map[uint64]struct{}, popSize),
+	}
+}
+
+func (d *diversityMeter) measure(pop *population) Diversity {
+	best := 0
+	for i, v := range pop.scores {
+		if v < pop.scores[best] {
+			best = i
+		}
+	}
+	for i, job := range pop.perms[best] {
+		d.pos[job] = i
+	}
+	clear(d.seen)
+
+	n := len(d.pos)
+	hamming, shift := 0, 0
+	for _, p := range pop.perms {
+		for i, job := range p {
+			if d.pos[job] != i {
+				hamming++
+				if d.pos[job] > i {
+					shift += d.pos[job] - i
+				} else {
+					shift += i - d.pos[job]
+				}
+			}
+		}
+		d.seen[permHash(p)] = struct{}{}
+	}
+
+	total := float64(len(pop.perms) * n)
+	return Diversity{
+		Hamming:  float64(hamming) / total,
+		Position: float64(shift) / total / float64(n),
+		Unique:   len(d.seen),
+	}
+}
+
+// maxShuffleRetries ограничивает поиск новой случайной перестановки при отсеве дубликатов:
+// для малых n различных перестановок может не хватить.
+const maxShuffleRetries = 16
+
+// eliminateDuplicates заменяет повторные вхождения особей случайными перестановками.
+// Возвращает число заменённых особей.
+func (r *run) eliminateDuplicates(pop *population, seen map[uint64]struct{}) int {
+	clear(seen)
+	replaced := 0
+	for i, p := range pop.perms {
+		h := permHash(p)
+		if _, dup := seen[h]; !dup {
+			seen[h] = struct{}{}
+			continue
+		}
+		for tries := 0; tries < maxShuffleRetries; tries++ {
+			ls.ShufflePermutation(p, r.s.Rng)
+			h = permHash(p)
+			if _, dup := seen[h]; !dup {
+				break
+			}
+		}
+		seen[h] = struct{}{}
+		pop.scores[i] = r.eval.MustMakespan(p)
+		pop.polished[i] = false
+		r.evaluations++
+		r.update(pop.scores[i], p)
+		replaced++
+	}
+	return replaced
+}
+
+// restart сохраняет RestartKeep лучших особей и заменяет остальные случайными.
+func (r *run) restart(pop *population, idxs []int) {
+	sortByScore(idxs, pop.scores)
+	for _, i := range idxs[r.s.Cfg.RestartKeep:] {
+		ls.ShufflePermutation(pop.perms[i], r.s.Rng)
+		pop.scores[i] = r.eval.MustMakespan(pop.perms[i])
+		pop.polished[i] = false
+		r.evaluations++
+		r.update(pop.scores[i], pop.perms[i])
+	}
+}
+
+// rebuildHashes пересчитывает учёт особей стационарной схемы.
+func rebuildHashes(pop *population, hashes map[uint64]int) {
+	clear(hashes)
+	for _, p := range pop.perms {
+		hashes[permHash(p)]++
+	}
+}
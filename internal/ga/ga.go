@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"flowShop/internal/flowshop"
+	"flowShop/internal/ls"
 	"flowShop/internal/opt"
 )
 
@@ -15,6 +16,9 @@ import (
 type Solver struct {
 	Cfg Config
 	Rng *rand.Rand
+
+	// Progress — необязательный обработчик, вызываемый после каждого поколения
+	Progress func(Progress)
 }
 
 // New возвращает новый GA-солвер с валидацией конфигурации, с использованием инициализированного генератора случайных чисел.
@@ -29,6 +33,66 @@ func New(cfg Config, rng *rand.Rand) (*Solver, error) {
 	return &Solver{Cfg: cfg, Rng: rng}, nil
 }
 
+// population — популяция особей с их приспособленностями.
+type population struct {
+	perms  [][]int
+	scores []int
+
+	// polished — приспособленность особи уже получена локальным спуском
+	// (используется при спуске по элите, чтобы не повторять его)
+	polished []bool
+}
+
+func newPopulation(size, jobs int) *population {
+	backing := make([]int, size*jobs)
+	perms := make([][]int, size)
+	for i := 0; i < size; i++ {
+		perms[i] = backing[i*jobs : (i+1)*jobs]
+	}
+	return &population{
+		perms:    perms,
+		scores:   make([]int, size),
+		polished: make([]bool, size),
+	}
+}
+
+// set записывает особь в позицию i.
+func (p *population) set(i int, perm []int, score int, polished bool) {
+	copy(p.perms[i], perm)
+	p.scores[i] = score
+	p.polished[i] = polished
+}
+
+// run — состояние одного запуска GA.
+type run struct {
+	s    *Solver
+	ctx  context.Context
+	eval *flowshop.Evaluator
+	mem  *memetic
+
+	sel       *selector
+	crossover crossoverFunc
+	mutate    func(p []int, rng *rand.Rand)
+
+	// adapt — адаптивный режим (nil — постоянные параметры)
+	adapt *adaptation
+
+	// mark и stamp используются кроссовером для отметки уже включённых работ
+	mark  []int
+	stamp int
+
+	// Временные буферы потомков: второй потомок при нечётном числе мест
+	// и пара потомков стационарной схемы
+	scratch, childA, childB []int
+
+	bestPerm     []int
+	bestMakespan int
+	evaluations  int
+
+	// rejected — число потомков, отвергнутых как дубликаты
+	rejected int
+}
+
 // Solve — реализация эвристики.
 func (s *Solver) Solve(ctx context.Context, inst *flowshop.Instance) (opt.Result, error) {
 	start := time.Now()
@@ -44,178 +108,488 @@ func (s *Solver) Solve(ctx context.Context, inst *flowshop.Instance) (opt.Result
 		return opt.Result{}, fmt.Errorf("генератор случайных чисел не инициализирован (nil)")
 	}
 
+	isl, err := newIsland(ctx, s, inst)
+	if err != nil {
+		return opt.Result{}, err
+	}
+
+	for gen := 0; gen < s.Cfg.Generations; gen++ {
+		// Для поддержки отмены через context
+		if err := ctx.Err(); err != nil {
+			res := ToOptResult(
+				isl.r.bestPerm,
+				isl.r.bestMakespan,
+				isl.evals(),
+				gen,
+				map[string]any{"stopped": "context"},
+			)
+			res.Duration = time.Since(start)
+			return res, err
+		}
+		isl.step(gen)
+	}
+
+	meta := map[string]any{
+		"population":  s.Cfg.Population,
+		"generations": s.Cfg.Generations,
+		"elite":       s.Cfg.Elite,
+		"crossover":   string(s.Cfg.Crossover),
+		"mutation":    string(s.Cfg.Mutation),
+		"selection":   string(s.Cfg.Selection),
+		"replacement": string(s.Cfg.Replacement),
+	}
+	isl.meta(meta)
+	res := ToOptResult(
+		isl.r.bestPerm,
+		isl.r.bestMakespan,
+		isl.evals(),
+		s.Cfg.Generations,
+		meta,
+	)
+	res.Duration = time.Since(start)
+	return res, nil
+}
+
+// island — популяция GA со всем состоянием поиска.
+// Solver работает с одной популяцией, IslandSolver — с несколькими.
+type island struct {
+	s *Solver
+	r *run
+
+	// Текущая популяция и вспомогательные: потомки и (для μ+λ) результат слияния
+	cur, next, merged *population
+
+	// Учёт особей популяции для отсева дубликатов в стационарной схеме
+	hashes map[uint64]int
+
+	// Индексы для сортировки популяции по приспособленности
+	order, idxs []int
+
+	// Показатели разнообразия, отсев дубликатов и перезапуски
+	meter              *diversityMeter
+	trackDiversity     bool
+	seen               map[uint64]struct{}
+	replaced, restarts int
+}
+
+// newIsland создаёт и оценивает начальную популяцию.
+// Конфигурация s.Cfg должна быть проверена заранее.
+func newIsland(ctx context.Context, s *Solver, inst *flowshop.Instance) (*island, error) {
 	// Оценщик значения целевой функции для flow-shop задачи
 	eval, err := flowshop.NewEvaluator(inst)
 	if err != nil {
-		return opt.Result{}, err
+		return nil, err
+	}
+
+	// Локальный спуск меметического варианта (nil — отключён)
+	mem, err := newMemetic(s.Cfg, inst)
+	if err != nil {
+		return nil, err
 	}
 
 	jobs := inst.Jobs
 	popSize := s.Cfg.Population
 
-	// Вспомогательная анонимная функция для создания двумерного массива перестановок
-	makePerms := func() [][]int {
-		backing := make([]int, popSize*jobs)
-		perms := make([][]int, popSize)
-		for i := 0; i < popSize; i++ {
-			perms[i] = backing[i*jobs : (i+1)*jobs]
-		}
-		return perms
+	r := &run{
+		s:         s,
+		ctx:       ctx,
+		eval:      eval,
+		mem:       mem,
+		sel:       newSelector(s.Cfg),
+		crossover: crossoverOperator(s.Cfg.Crossover),
+		mutate:    mutationOperator(s.Cfg.Mutation, s.Cfg.MutationSwaps),
+		adapt:     newAdaptation(s.Cfg),
+		mark:      make([]int, jobs),
+		stamp:     1,
+		scratch:   make([]int, jobs),
+		childA:    make([]int, jobs),
+		childB:    make([]int, jobs),
+		bestPerm:  make([]int, jobs),
 	}
 
-	// Две популяции: текущая (A) и следующая (B)
-	permsA := makePerms()
-	permsB := makePerms()
-	scoresA := make([]int, popSize)
-	scoresB := make([]int, popSize)
-
 	// Инициализация начальной популяции
+	cur := newPopulation(popSize, jobs)
 	for i := 0; i < popSize; i++ {
-		initPermutation(permsA[i])
-		shufflePermutation(permsA[i], s.Rng)
-		ms := eval.MustMakespan(permsA[i])
-		scoresA[i] = ms
+		ls.InitPermutation(cur.perms[i])
+		ls.ShufflePermutation(cur.perms[i], s.Rng)
+		ms := eval.MustMakespan(cur.perms[i])
+		cur.scores[i] = ms
 	}
-	evaluations := popSize
+	r.evaluations = popSize
 
 	// Поиск лучшего решения в начальной популяции
-	bestPerm := make([]int, jobs)
-	bestMakespan := scoresA[0]
-	copy(bestPerm, permsA[0])
+	r.bestMakespan = cur.scores[0]
+	copy(r.bestPerm, cur.perms[0])
 	for i := 1; i < popSize; i++ {
-		if scoresA[i] < bestMakespan {
-			bestMakespan = scoresA[i]
-			copy(bestPerm, permsA[i])
-		}
+		r.update(cur.scores[i], cur.perms[i])
 	}
 
-	// Массивы для кроссовера:
-	// mark и stamp используются для отметки уже включённых работ
-	mark := make([]int, jobs)
-	stamp := 1
+	isl := &island{
+		s:              s,
+		r:              r,
+		cur:            cur,
+		next:           newPopulation(popSize, jobs),
+		idxs:           make([]int, 2*popSize),
+		order:          make([]int, popSize),
+		meter:          newDiversityMeter(jobs, popSize),
+		trackDiversity: s.Progress != nil || s.Cfg.RestartDiversity > 0,
+		seen:           make(map[uint64]struct{}, popSize),
+	}
+	if s.Cfg.Replacement == ReplacementMuPlusLambda {
+		isl.merged = newPopulation(popSize, jobs)
+	}
+	if s.Cfg.Replacement == ReplacementSteadyState {
+		isl.hashes = make(map[uint64]int, popSize)
+		rebuildHashes(cur, isl.hashes)
+	}
+	for i := range isl.order {
+		isl.order[i] = i
+	}
+	return isl, nil
+}
 
-	// Временный буфер для второго потомка,
-	// если в популяции остаётся нечётное число мест
-	scratchChild := make([]int, jobs)
+// step выполняет одно поколение gen (с нуля).
+func (isl *island) step(gen int) {
+	s, r := isl.s, isl.r
+	popSize := len(isl.cur.perms)
 
-	// Индексы для сортировки популяции по приспособленности
-	idxs := make([]int, popSize)
-	for i := range idxs {
-		idxs[i] = i
+	switch s.Cfg.Replacement {
+	case ReplacementGenerational:
+		r.generational(isl.cur, isl.next, isl.order)
+		isl.cur, isl.next = isl.next, isl.cur
+	case ReplacementSteadyState:
+		r.steadyState(isl.cur, isl.hashes)
+	case ReplacementMuPlusLambda:
+		r.muPlusLambda(isl.cur, isl.next, isl.merged, isl.idxs)
+		isl.cur, isl.merged = isl.merged, isl.cur
 	}
+	cur := isl.cur
 
-	for gen := 0; gen < s.Cfg.Generations; gen++ {
-		// Для поддержки отмены через context
-		if err := ctx.Err(); err != nil {
-			res := ToOptResult(
-				bestPerm,
-				bestMakespan,
-				evaluations,
-				gen,
-				map[string]any{"stopped": "context"},
-			)
-			res.Duration = time.Since(start)
-			return res, err
+	// Локальный спуск по элите нового поколения
+	if r.mem != nil && s.Cfg.LocalSearchScope == LocalSearchElite {
+		r.polishElite(cur, isl.idxs[:popSize], isl.hashes)
+	}
+
+	// Отсев дубликатов
+	if s.Cfg.EliminateDuplicates {
+		if k := r.eliminateDuplicates(cur, isl.seen); k > 0 {
+			isl.replaced += k
+			isl.populationChanged()
 		}
+	}
 
-		// Сортировка индексов по возрастанию значения целевой функции
-		sort.Slice(idxs, func(i, j int) bool {
-			return scoresA[idxs[i]] < scoresA[idxs[j]]
+	if !isl.trackDiversity {
+		return
+	}
+	div := isl.meter.measure(cur)
+
+	// Перезапуск сошедшейся популяции с сохранением лучших особей
+	if s.Cfg.RestartDiversity > 0 && div.Hamming < s.Cfg.RestartDiversity && gen+1 < s.Cfg.Generations {
+		r.restart(cur, isl.idxs[:popSize])
+		isl.restarts++
+		isl.populationChanged()
+		div = isl.meter.measure(cur)
+	}
+
+	if s.Progress != nil {
+		s.Progress(Progress{
+			Generation:   gen + 1,
+			BestMakespan: r.bestMakespan,
+			Diversity:    div,
+			Restarts:     isl.restarts,
 		})
+	}
+}
+
+// populationChanged обновляет учёт особей после замены части популяции вне схемы замены.
+func (isl *island) populationChanged() {
+	if isl.hashes != nil {
+		rebuildHashes(isl.cur, isl.hashes)
+	}
+}
 
-		write := 0
+// evals возвращает общее число оценок, включая локальный спуск.
+func (isl *island) evals() int {
+	return isl.r.evaluations + isl.r.mem.evals()
+}
 
-		// Элитизм (переносим лучших особей без изменений)
-		for e := 0; e < s.Cfg.Elite; e++ {
-			src := idxs[e]
-			copy(permsB[write], permsA[src])
-			scoresB[write] = scoresA[src]
+// meta дополняет Meta показателями популяции.
+func (isl *island) meta(meta map[string]any) {
+	s, r := isl.s, isl.r
+	if s.Cfg.Replacement == ReplacementSteadyState {
+		meta["duplicates_rejected"] = r.rejected
+	}
+	div := isl.meter.measure(isl.cur)
+	meta["diversity_hamming"] = div.Hamming
+	meta["diversity_position"] = div.Position
+	meta["unique"] = div.Unique
+	if s.Cfg.EliminateDuplicates {
+		meta["duplicates_replaced"] = isl.replaced
+	}
+	if s.Cfg.RestartDiversity > 0 {
+		meta["restarts"] = isl.restarts
+	}
+	if r.mem != nil {
+		r.mem.meta(s.Cfg, meta)
+	}
+	if r.adapt != nil {
+		r.adapt.meta(meta)
+	}
+}
+
+// generational — поколенческая замена: Elite лучших особей переносятся без изменений,
+// остальные места занимают потомки.
+func (r *run) generational(cur, next *population, idxs []int) {
+	popSize := len(cur.perms)
+
+	// Сортировка индексов по возрастанию значения целевой функции
+	// (порядок индексов сохраняется между поколениями)
+	sort.Slice(idxs, func(i, j int) bool {
+		return cur.scores[idxs[i]] < cur.scores[idxs[j]]
+	})
+
+	write := 0
+
+	// Элитизм (переносим лучших особей без изменений)
+	for e := 0; e < r.s.Cfg.Elite; e++ {
+		src := idxs[e]
+		next.set(write, cur.perms[src], cur.scores[src], cur.polished[src])
+		write++
+	}
+
+	// Генерация остальных особей нового поколения
+	need := popSize - write
+	r.sel.prepare(cur.scores, need+need%2, r.s.Rng)
+	for write < popSize {
+		child1 := next.perms[write]
+		hasSecond := write+1 < popSize
+		child2 := r.scratch
+		if hasSecond {
+			child2 = next.perms[write+1]
+		}
+		r.breed(cur, child1, child2, hasSecond)
+
+		// Оценка первого потомка
+		next.scores[write], next.polished[write] = r.evaluate(child1, 0)
+		write++
+
+		// Оценка второго потомка
+		if hasSecond {
+			next.scores[write], next.polished[write] = r.evaluate(child2, 1)
 			write++
 		}
+	}
+}
 
-		// Генерация остальных особей нового поколения
-		for write < popSize {
-			// Турнирный отбор
-			p1 := tournamentSelect(scoresA, s.Cfg.TournamentSize, s.Rng)
-			p2 := tournamentSelect(scoresA, s.Cfg.TournamentSize, s.Rng)
-			if popSize > 1 {
-				for p2 == p1 {
-					p2 = tournamentSelect(scoresA, s.Cfg.TournamentSize, s.Rng)
-				}
+// steadyState — стационарная схема: за поколение выполняется popSize/2 шагов,
+// на каждом из которых два потомка по очереди вытесняют худшую особь.
+// Потомки, совпадающие с особью популяции, отвергаются без оценки.
+func (r *run) steadyState(cur *population, hashes map[uint64]int) {
+	steps := len(cur.perms) / 2
+	if steps < 1 {
+		steps = 1
+	}
+	for step := 0; step < steps; step++ {
+		r.sel.prepare(cur.scores, 2, r.s.Rng)
+		r.breed(cur, r.childA, r.childB, true)
+		for k, child := range [2][]int{r.childA, r.childB} {
+			if hashes[permHash(child)] > 0 {
+				r.rejected++
+				continue
 			}
-
-			child1 := permsB[write]
-			hasSecond := write+1 < popSize
-			child2 := scratchChild
-			if hasSecond {
-				child2 = permsB[write+1]
+			ms, polished := r.evaluate(child, k)
+			h := permHash(child)
+			if polished && hashes[h] > 0 {
+				// Ламарковский спуск мог привести к уже имеющейся особи
+				r.rejected++
+				continue
 			}
 
-			// Кроссовер
-			if s.Rng.Float64() < s.Cfg.CrossoverRate {
-				orderCrossoverOX(
-					permsA[p1],
-					permsA[p2],
-					child1,
-					child2,
-					s.Rng,
-					mark,
-					&stamp,
-				)
-			} else {
-				copy(child1, permsA[p1])
-				if hasSecond {
-					copy(child2, permsA[p2])
+			worst := 0
+			for i := 1; i < len(cur.scores); i++ {
+				if cur.scores[i] > cur.scores[worst] {
+					worst = i
 				}
 			}
-
-			// Мутация
-			if s.Rng.Float64() < s.Cfg.MutationRate {
-				mutateSwap(child1, s.Rng)
-			}
-			if hasSecond && s.Rng.Float64() < s.Cfg.MutationRate {
-				mutateSwap(child2, s.Rng)
+			if ms >= cur.scores[worst] {
+				continue
 			}
+			hashes[permHash(cur.perms[worst])]--
+			cur.set(worst, child, ms, polished)
+			hashes[h]++
+		}
+	}
+}
 
-			// Оценка первого потомка
-			ms1 := eval.MustMakespan(child1)
-			scoresB[write] = ms1
-			evaluations++
-			if ms1 < bestMakespan {
-				bestMakespan = ms1
-				copy(bestPerm, child1)
-			}
-			write++
+// muPlusLambda — схема (μ+λ): λ = μ потомков оцениваются в off,
+// затем из объединения родителей и потомков в dst отбираются μ лучших
+// (при равенстве предпочтение родителям).
+func (r *run) muPlusLambda(cur, off, dst *population, idxs []int) {
+	popSize := len(cur.perms)
 
-			// Оценка второго потомка
-			if hasSecond {
-				ms2 := eval.MustMakespan(child2)
-				scoresB[write] = ms2
-				evaluations++
-				if ms2 < bestMakespan {
-					bestMakespan = ms2
-					copy(bestPerm, child2)
-				}
-				write++
+	r.sel.prepare(cur.scores, popSize+popSize%2, r.s.Rng)
+	for write := 0; write < popSize; write += 2 {
+		hasSecond := write+1 < popSize
+		child2 := r.scratch
+		if hasSecond {
+			child2 = off.perms[write+1]
+		}
+		r.breed(cur, off.perms[write], child2, hasSecond)
+		off.scores[write], off.polished[write] = r.evaluate(off.perms[write], 0)
+		if hasSecond {
+			off.scores[write+1], off.polished[write+1] = r.evaluate(child2, 1)
+		}
+	}
+
+	// Индексы [0, popSize) — родители, [popSize, 2·popSize) — потомки
+	score := func(i int) int {
+		if i < popSize {
+			return cur.scores[i]
+		}
+		return off.scores[i-popSize]
+	}
+	for i := range idxs {
+		idxs[i] = i
+	}
+	sort.SliceStable(idxs, func(a, b int) bool {
+		return score(idxs[a]) < score(idxs[b])
+	})
+	for k := 0; k < popSize; k++ {
+		src := cur
+		i := idxs[k]
+		if i >= popSize {
+			src, i = off, i-popSize
+		}
+		dst.set(k, src.perms[i], src.scores[i], src.polished[i])
+	}
+}
+
+// breed выбирает двух родителей из cur и строит потомков c1 и c2
+// кроссовером и мутацией. При !hasSecond c2 — временный буфер и не мутирует.
+func (r *run) breed(cur *population, c1, c2 []int, hasSecond bool) {
+	s := r.s
+	popSize := len(cur.perms)
+
+	// Отбор родителей
+	p1 := r.sel.pick(s.Rng)
+	p2 := r.sel.pick(s.Rng)
+	if popSize > 1 {
+		for tries := 0; p2 == p1 && tries < maxParentRetries; tries++ {
+			p2 = r.sel.pick(s.Rng)
+		}
+	}
+
+	// Вероятности и операторы (в адаптивном режиме зависят от родителей и статистики)
+	pc, pm1, pm2 := s.Cfg.CrossoverRate, s.Cfg.MutationRate, s.Cfg.MutationRate
+	if r.adapt != nil {
+		pc, pm1, pm2 = r.adapt.begin(s.Cfg, cur.scores, p1, p2)
+	}
+
+	// Кроссовер
+	if s.Rng.Float64() < pc {
+		crossover := r.crossover
+		if r.adapt != nil {
+			crossover = r.adapt.crossover(crossover, s.Rng)
+		}
+		crossover(
+			cur.perms[p1],
+			cur.perms[p2],
+			c1,
+			c2,
+			s.Rng,
+			r.mark,
+			&r.stamp,
+		)
+	} else {
+		copy(c1, cur.perms[p1])
+		if hasSecond {
+			copy(c2, cur.perms[p2])
+		}
+	}
+
+	// Мутация
+	if s.Rng.Float64() < pm1 {
+		r.mutation(0)(c1, s.Rng)
+	}
+	if hasSecond && s.Rng.Float64() < pm2 {
+		r.mutation(1)(c2, s.Rng)
+	}
+}
+
+// mutation возвращает оператор мутации для потомка k пары.
+func (r *run) mutation(k int) func(p []int, rng *rand.Rand) {
+	if r.adapt != nil {
+		return r.adapt.mutation(r.mutate, k, r.s.Rng)
+	}
+	return r.mutate
+}
+
+// maxParentRetries ограничивает повторный выбор второго родителя,
+// совпадающего с первым (схемы отбора могут многократно возвращать одну особь).
+const maxParentRetries = 16
+
+// evaluate оценивает потомка k (0 или 1) последней пары, начисляет награду
+// адаптивным операторам и при необходимости применяет к нему локальный спуск.
+// Возвращает приспособленность и признак того, что спуск был применён.
+func (r *run) evaluate(child []int, k int) (int, bool) {
+	ms := r.eval.MustMakespan(child)
+	r.evaluations++
+	if r.adapt != nil {
+		r.adapt.credit(k, ms)
+	}
+	polished := false
+	sol := child
+	if r.mem != nil {
+		switch r.s.Cfg.LocalSearchScope {
+		case LocalSearchAll:
+			ms, sol = r.mem.improve(r.ctx, child, ms)
+			polished = true
+		case LocalSearchFraction:
+			if r.s.Rng.Float64() < r.s.Cfg.LocalSearchRate {
+				ms, sol = r.mem.improve(r.ctx, child, ms)
+				polished = true
 			}
 		}
+	}
+	r.update(ms, sol)
+	return ms, polished
+}
 
-		// Смена поколений
-		permsA, permsB = permsB, permsA
-		scoresA, scoresB = scoresB, scoresA
+// polishElite применяет локальный спуск к Elite лучшим особям популяции,
+// ещё не прошедшим его.
+func (r *run) polishElite(cur *population, idxs []int, hashes map[uint64]int) {
+	sortByScore(idxs, cur.scores)
+	for _, i := range idxs[:r.s.Cfg.Elite] {
+		if cur.polished[i] {
+			continue
+		}
+		if hashes != nil {
+			hashes[permHash(cur.perms[i])]--
+		}
+		ms, sol := r.mem.improve(r.ctx, cur.perms[i], cur.scores[i])
+		cur.scores[i] = ms
+		cur.polished[i] = true
+		if hashes != nil {
+			hashes[permHash(cur.perms[i])]++
+		}
+		r.update(ms, sol)
 	}
+}
 
-	res := ToOptResult(
-		bestPerm,
-		bestMakespan,
-		evaluations,
-		s.Cfg.Generations,
-		map[string]any{
-			"population":  s.Cfg.Population,
-			"generations": s.Cfg.Generations,
-			"elite":       s.Cfg.Elite,
-		},
-	)
-	res.Duration = time.Since(start)
-	return res, nil
+// update обновляет лучшее найденное решение.
+func (r *run) update(ms int, perm []int) {
+	if ms < r.bestMakespan {
+		r.bestMakespan = ms
+		copy(r.bestPerm, perm)
+	}
+}
+
+// sortByScore заполняет idxs индексами 0..len(idxs)-1 по возрастанию scores.
+func sortByScore(idxs []int, scores []int) {
+	for i := range idxs {
+		idxs[i] = i
+	}
+	sort.Slice(idxs, func(i, j int) bool {
+		return scores[idxs[i]] < scores[idxs[j]]
+	})
 }
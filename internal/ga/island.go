@@ -0,0 +1,205 @@
+package ga
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"flowShop/internal/flowshop"
+	"flowShop/internal/opt"
+)
+
+// IslandSolver — островная модель GA: подпопуляции развиваются параллельно
+// в отдельных горутинах и между эпохами синхронно обмениваются мигрантами.
+//
+// Каждый остров получает собственный генератор, сид которого берётся из Rng,
+// а миграция выполняется в одной горутине после завершения эпохи на всех островах.
+// Поэтому результат при одинаковом сиде не зависит от планирования горутин.
+type IslandSolver struct {
+	Cfg IslandConfig
+	Rng *rand.Rand
+}
+
+func NewIsland(cfg IslandConfig, rng *rand.Rand) (*IslandSolver, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if rng == nil {
+		return nil, fmt.Errorf("генератор случайных чисел не инициализирован (nil)")
+	}
+	return &IslandSolver{Cfg: cfg, Rng: rng}, nil
+}
+
+// Solve — реализация opt.Optimizer.
+func (s *IslandSolver) Solve(ctx context.Context, inst *flowshop.Instance) (opt.Result, error) {
+	start := time.Now()
+
+	if err := inst.Validate(); err != nil {
+		return opt.Result{}, err
+	}
+	if err := s.Cfg.Validate(); err != nil {
+		return opt.Result{}, err
+	}
+	if s.Rng == nil {
+		return opt.Result{}, fmt.Errorf("генератор случайных чисел не инициализирован (nil)")
+	}
+
+	// Острова с собственными генераторами
+	islands := make([]*island, s.Cfg.Islands)
+	for i := range islands {
+		sub := &Solver{
+			Cfg: s.Cfg.Island,
+			Rng: rand.New(rand.NewSource(s.Rng.Int63())),
+		}
+		isl, err := newIsland(ctx, sub, inst)
+		if err != nil {
+			return opt.Result{}, err
+		}
+		islands[i] = isl
+	}
+
+	m := &migration{
+		cfg:       s.Cfg,
+		emigrants: newPopulation(s.Cfg.Islands*s.Cfg.Migrants, inst.Jobs),
+	}
+
+	gens := s.Cfg.Island.Generations
+	for gen := 0; gen < gens; {
+		// Для поддержки отмены через context
+		if err := ctx.Err(); err != nil {
+			res := s.result(islands, gen, m.count, map[string]any{"stopped": "context"})
+			res.Duration = time.Since(start)
+			return res, err
+		}
+
+		// Эпоха: острова независимо выполняют до MigrationInterval поколений
+		epoch := min(s.Cfg.MigrationInterval, gens-gen)
+		var wg sync.WaitGroup
+		for _, isl := range islands {
+			wg.Add(1)
+			go func(isl *island) {
+				defer wg.Done()
+				for g := gen; g < gen+epoch; g++ {
+					if ctx.Err() != nil {
+						return
+					}
+					isl.step(g)
+				}
+			}(isl)
+		}
+		wg.Wait()
+		gen += epoch
+
+		if gen < gens && ctx.Err() == nil {
+			m.migrate(islands)
+		}
+	}
+
+	cfg := s.Cfg.Island
+	res := s.result(islands, gens, m.count, map[string]any{
+		"islands":             s.Cfg.Islands,
+		"island_population":   cfg.Population,
+		"generations":         cfg.Generations,
+		"topology":            string(s.Cfg.Topology),
+		"migration_interval":  s.Cfg.MigrationInterval,
+		"migrants":            s.Cfg.Migrants,
+		"migrant_replacement": string(s.Cfg.Replacement),
+		"crossover":           string(cfg.Crossover),
+		"mutation":            string(cfg.Mutation),
+		"selection":           string(cfg.Selection),
+		"replacement":         string(cfg.Replacement),
+	})
+	res.Duration = time.Since(start)
+	return res, nil
+}
+
+// result собирает лучшее решение по всем островам
+// (при равенстве — с острова с меньшим номером).
+func (s *IslandSolver) result(islands []*island, gens, migrations int, meta map[string]any) opt.Result {
+	best := islands[0].r
+	evals := 0
+	islandBest := make([]int, len(islands))
+	for i, isl := range islands {
+		if isl.r.bestMakespan < best.bestMakespan {
+			best = isl.r
+		}
+		evals += isl.evals()
+		islandBest[i] = isl.r.bestMakespan
+	}
+	meta["migrations"] = migrations
+	meta["island_best"] = islandBest
+	return ToOptResult(best.bestPerm, best.bestMakespan, evals, gens, meta)
+}
+
+// migration выполняет синхронный обмен мигрантами между островами.
+type migration struct {
+	cfg IslandConfig
+
+	// emigrants — снимок лучших особей всех островов: остров i занимает
+	// позиции [i·Migrants, (i+1)·Migrants)
+	emigrants *population
+	count     int
+}
+
+// migrate копирует Migrants лучших особей каждого острова соседям по топологии.
+// Эмигранты отбираются до замещения, поэтому порядок обработки островов не важен.
+// Мигрант, совпадающий с особью острова-получателя, не принимается.
+func (m *migration) migrate(islands []*island) {
+	k, per := len(islands), m.cfg.Migrants
+
+	// Снимок эмигрантов
+	for i, isl := range islands {
+		popSize := len(isl.cur.perms)
+		idxs := isl.idxs[:popSize]
+		sortByScore(idxs, isl.cur.scores)
+		for j := 0; j < per; j++ {
+			src := idxs[j]
+			m.emigrants.set(i*per+j, isl.cur.perms[src], isl.cur.scores[src], isl.cur.polished[src])
+		}
+	}
+
+	for dst, isl := range islands {
+		cur := isl.cur
+		popSize := len(cur.perms)
+
+		// Кандидаты на вытеснение берутся с конца idxs: худшие или случайные, кроме лучшей
+		idxs := isl.idxs[:popSize]
+		sortByScore(idxs, cur.scores)
+		if m.cfg.Replacement == MigrantReplaceRandom {
+			tail := idxs[1:]
+			isl.s.Rng.Shuffle(len(tail), func(a, b int) {
+				tail[a], tail[b] = tail[b], tail[a]
+			})
+		}
+		victim := popSize - 1
+
+		clear(isl.seen)
+		for _, p := range cur.perms {
+			isl.seen[permHash(p)] = struct{}{}
+		}
+
+		for off := 1; off < k; off++ {
+			src := (dst - off + k) % k
+			if m.cfg.Topology == TopologyRing && off > 1 {
+				break
+			}
+			for j := 0; j < per; j++ {
+				e := src*per + j
+				perm := m.emigrants.perms[e]
+				h := permHash(perm)
+				if _, dup := isl.seen[h]; dup {
+					continue
+				}
+				isl.seen[h] = struct{}{}
+				// Рекорд острова не обновляется: эмигрант уже учтён на исходном острове,
+				// а при балдуиновском спуске его оценка не соответствует генотипу perm
+				cur.set(idxs[victim], perm, m.emigrants.scores[e], m.emigrants.polished[e])
+				victim--
+			}
+		}
+		isl.populationChanged()
+	}
+	m.count++
+}
@@ -0,0 +1,79 @@
+package ga
+
+import (
+	"context"
+
+	"flowShop/internal/flowshop"
+	"flowShop/internal/ls"
+)
+
+// memetic применяет локальный спуск к особям меметического GA
+// и ведёт счётчики для Meta.
+type memetic struct {
+	searcher *ls.Searcher
+	mode     LocalSearchMode
+
+	// buf — копия генотипа для болдуиновского режима
+	buf []int
+
+	evaluations  int
+	applications int
+	improvements int
+}
+
+// newMemetic возвращает nil, если локальный поиск отключён.
+func newMemetic(cfg Config, inst *flowshop.Instance) (*memetic, error) {
+	if !cfg.LocalSearch.Enabled() {
+		return nil, nil
+	}
+	searcher, err := ls.New(cfg.LocalSearch, inst)
+	if err != nil {
+		return nil, err
+	}
+	return &memetic{
+		searcher: searcher,
+		mode:     cfg.LocalSearchMode,
+		buf:      make([]int, inst.Jobs),
+	}, nil
+}
+
+// improve запускает спуск от особи p со значением cost.
+// Возвращает приспособленность особи и найденную перестановку:
+// в ламарковском режиме это сама p (генотип заменён),
+// в болдуиновском — внутренний буфер, а p остаётся без изменений.
+func (m *memetic) improve(ctx context.Context, p []int, cost int) (int, []int) {
+	sol := p
+	if m.mode == LocalSearchBaldwinian {
+		copy(m.buf, p)
+		sol = m.buf
+	}
+	next, n := m.searcher.Improve(ctx, sol, cost)
+	m.evaluations += n
+	m.applications++
+	if next < cost {
+		m.improvements++
+	}
+	return next, sol
+}
+
+// evals возвращает число оценок, затраченных локальным спуском (0 для nil).
+func (m *memetic) evals() int {
+	if m == nil {
+		return 0
+	}
+	return m.evaluations
+}
+
+// meta дополняет Meta описанием меметического варианта.
+func (m *memetic) meta(cfg Config, meta map[string]any) {
+	meta["local_search"] = string(cfg.LocalSearch.Neighborhood)
+	meta["ls_strategy"] = string(cfg.LocalSearch.Strategy)
+	meta["ls_scope"] = string(cfg.LocalSearchScope)
+	meta["ls_mode"] = string(cfg.LocalSearchMode)
+	if cfg.LocalSearchScope == LocalSearchFraction {
+		meta["ls_rate"] = cfg.LocalSearchRate
+	}
+	meta["ls_evaluations"] = m.evaluations
+	meta["ls_applications"] = m.applications
+	meta["ls_improvements"] = m.improvements
+}
@@ -0,0 +1,88 @@
+package ga
+
+import (
+	"math/rand"
+
+	"flowShop/internal/ls"
+)
+
+// Mutation — оператор мутации перестановки.
+type Mutation string
+
+const (
+	// MutationSwap — обмен двух случайных работ.
+	MutationSwap Mutation = "swap"
+	// MutationInsert — перенос работы в другую позицию (shift).
+	MutationInsert Mutation = "insert"
+	// MutationInversion — разворот случайного отрезка.
+	MutationInversion Mutation = "inversion"
+	// MutationScramble — случайное перемешивание отрезка.
+	MutationScramble Mutation = "scramble"
+	// MutationMultiSwap — MutationSwaps последовательных обменов.
+	MutationMultiSwap Mutation = "multi-swap"
+)
+
+// mutationOperator возвращает реализацию оператора (nil для неизвестного).
+func mutationOperator(op Mutation, swaps int) func(p []int, rng *rand.Rand) {
+	switch op {
+	case MutationSwap:
+		return mutateSwap
+	case MutationInsert:
+		return mutateInsert
+	case MutationInversion:
+		return mutateInversion
+	case MutationScramble:
+		return mutateScramble
+	case MutationMultiSwap:
+		return func(p []int, rng *rand.Rand) {
+			for k := 0; k < swaps; k++ {
+				mutateSwap(p, rng)
+			}
+		}
+	}
+	return nil
+}
+
+// mutateInsert извлекает работу из случайной позиции и вставляет её в другую.
+func mutateInsert(p []int, rng *rand.Rand) {
+	if len(p) < 2 {
+		return
+	}
+	i := rng.Intn(len(p))
+	j := rng.Intn(len(p) - 1)
+	if j >= i {
+		j++
+	}
+	ls.ApplyInsert(p, i, j)
+}
+
+// mutateInversion разворачивает случайный отрезок длины не менее 2.
+func mutateInversion(p []int, rng *rand.Rand) {
+	if len(p) < 2 {
+		return
+	}
+	i, j := mutationSegment(len(p), rng)
+	ls.Reverse(p[i : j+1])
+}
+
+// mutateScramble перемешивает случайный отрезок длины не менее 2.
+func mutateScramble(p []int, rng *rand.Rand) {
+	if len(p) < 2 {
+		return
+	}
+	i, j := mutationSegment(len(p), rng)
+	ls.ShufflePermutation(p[i:j+1], rng)
+}
+
+// mutationSegment выбирает две различные позиции i < j.
+func mutationSegment(n int, rng *rand.Rand) (int, int) {
+	i := rng.Intn(n)
+	j := rng.Intn(n - 1)
+	if j >= i {
+		j++
+	}
+	if i > j {
+		i, j = j, i
+	}
+	return i, j
+}
@@ -0,0 +1,314 @@
+package ga
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"flowShop/internal/flowshop"
+	"flowShop/internal/ls"
+	"flowShop/internal/opt"
+)
+
+// NSGA2 — многокритериальный генетический алгоритм NSGA-II (Deb и др., 2002).
+// Использует те же операторы, что и Solver: турнирный отбор (по рангу фронта
+// и crowding distance), OX-кроссовер и swap-мутацию.
+type NSGA2 struct {
+	Cfg NSGA2Config
+	Rng *rand.Rand
+}
+
+// NewNSGA2 возвращает новый NSGA-II-солвер с валидацией конфигурации.
+func NewNSGA2(cfg NSGA2Config, rng *rand.Rand) (*NSGA2, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if rng == nil {
+		return nil, fmt.Errorf("генератор случайных чисел не инициализирован (nil)")
+	}
+	return &NSGA2{Cfg: cfg, Rng: rng}, nil
+}
+
+// nsgaPool — объединённая популяция родителей [0, N) и потомков [N, 2N).
+type nsgaPool struct {
+	perms [][]int
+	objs  [][]int
+	rank  []int
+	crowd []float64
+}
+
+func newNSGAPool(size, jobs, nObj int) *nsgaPool {
+	p := &nsgaPool{
+		perms: make([][]int, size),
+		objs:  make([][]int, size),
+		rank:  make([]int, size),
+		crowd: make([]float64, size),
+	}
+	permBacking := make([]int, size*jobs)
+	objBacking := make([]int, size*nObj)
+	for i := 0; i < size; i++ {
+		p.perms[i] = permBacking[i*jobs : (i+1)*jobs]
+		p.objs[i] = objBacking[i*nObj : (i+1)*nObj]
+	}
+	return p
+}
+
+// SolvePareto — основной цикл NSGA-II. Возвращает внешний архив
+// недоминируемых решений, накопленный за весь запуск.
+func (s *NSGA2) SolvePareto(ctx context.Context, inst *flowshop.Instance) (opt.ParetoResult, error) {
+	start := time.Now()
+
+	if err := inst.Validate(); err != nil {
+		return opt.ParetoResult{}, err
+	}
+	if err := s.Cfg.Validate(); err != nil {
+		return opt.ParetoResult{}, err
+	}
+	if err := flowshop.ValidateObjectives(inst, s.Cfg.Objectives); err != nil {
+		return opt.ParetoResult{}, err
+	}
+	if s.Rng == nil {
+		return opt.ParetoResult{}, fmt.Errorf("генератор случайных чисел не инициализирован (nil)")
+	}
+
+	eval, err := flowshop.NewEvaluator(inst)
+	if err != nil {
+		return opt.ParetoResult{}, err
+	}
+
+	jobs := inst.Jobs
+	popSize := s.Cfg.Population
+	objectives := s.Cfg.Objectives
+	nObj := len(objectives)
+
+	// Текущая объединённая популяция и буфер для следующей
+	cur := newNSGAPool(2*popSize, jobs, nObj)
+	next := newNSGAPool(2*popSize, jobs, nObj)
+
+	archive := &opt.Archive{}
+	sorter := newFrontSorter(2 * popSize)
+
+	// Инициализация начальной популяции
+	for i := 0; i < popSize; i++ {
+		ls.InitPermutation(cur.perms[i])
+		ls.ShufflePermutation(cur.perms[i], s.Rng)
+		eval.MustObjectives(cur.perms[i], objectives, cur.objs[i])
+		archive.Add(cur.perms[i], cur.objs[i])
+	}
+	evaluations := popSize
+
+	fronts := sorter.sort(cur.objs[:popSize], cur.rank)
+	for _, f := range fronts {
+		crowdingDistance(f, cur.objs, cur.crowd)
+	}
+
+	// Сравнение по рангу фронта, затем по crowding distance
+	better := func(a, b int) bool {
+		if cur.rank[a] != cur.rank[b] {
+			return cur.rank[a] < cur.rank[b]
+		}
+		return cur.crowd[a] > cur.crowd[b]
+	}
+
+	mark := make([]int, jobs)
+	stamp := 1
+	scratchChild := make([]int, jobs)
+
+	for gen := 0; gen < s.Cfg.Generations; gen++ {
+		// Для поддержки отмены через context
+		if err := ctx.Err(); err != nil {
+			return paretoResult(objectives, archive, evaluations, gen, start, map[string]any{"stopped": "context"}), err
+		}
+
+		// Генерация потомков в позиции [N, 2N)
+		for write := popSize; write < 2*popSize; {
+			p1 := tournamentSelectFunc(popSize, s.Cfg.TournamentSize, s.Rng, better)
+			p2 := tournamentSelectFunc(popSize, s.Cfg.TournamentSize, s.Rng, better)
+			for p2 == p1 {
+				p2 = tournamentSelectFunc(popSize, s.Cfg.TournamentSize, s.Rng, better)
+			}
+
+			child1 := cur.perms[write]
+			hasSecond := write+1 < 2*popSize
+			child2 := scratchChild
+			if hasSecond {
+				child2 = cur.perms[write+1]
+			}
+
+			if s.Rng.Float64() < s.Cfg.CrossoverRate {
+				orderCrossoverOX(cur.perms[p1], cur.perms[p2], child1, child2, s.Rng, mark, &stamp)
+			} else {
+				copy(child1, cur.perms[p1])
+				copy(child2, cur.perms[p2])
+			}
+			if s.Rng.Float64() < s.Cfg.MutationRate {
+				mutateSwap(child1, s.Rng)
+			}
+			if hasSecond && s.Rng.Float64() < s.Cfg.MutationRate {
+				mutateSwap(child2, s.Rng)
+			}
+
+			eval.MustObjectives(child1, objectives, cur.objs[write])
+			archive.Add(child1, cur.objs[write])
+			evaluations++
+			write++
+			if hasSecond {
+				eval.MustObjectives(child2, objectives, cur.objs[write])
+				archive.Add(child2, cur.objs[write])
+				evaluations++
+				write++
+			}
+		}
+
+		// Недоминируемая сортировка объединённой популяции и отбор N выживших
+		fronts := sorter.sort(cur.objs, cur.rank)
+		write := 0
+		for _, f := range fronts {
+			if write >= popSize {
+				break
+			}
+			crowdingDistance(f, cur.objs, cur.crowd)
+			if write+len(f) > popSize {
+				// Последний фронт не помещается целиком:
+				// берём наиболее разреженные решения
+				sort.SliceStable(f, func(i, j int) bool {
+					return cur.crowd[f[i]] > cur.crowd[f[j]]
+				})
+				f = f[:popSize-write]
+			}
+			for _, idx := range f {
+				copy(next.perms[write], cur.perms[idx])
+				copy(next.objs[write], cur.objs[idx])
+				next.rank[write] = cur.rank[idx]
+				next.crowd[write] = cur.crowd[idx]
+				write++
+			}
+		}
+
+		// Смена поколений
+		cur, next = next, cur
+	}
+
+	res := paretoResult(objectives, archive, evaluations, s.Cfg.Generations, start, map[string]any{
+		"population":  s.Cfg.Population,
+		"generations": s.Cfg.Generations,
+		"front_size":  len(archive.Solutions),
+	})
+	return res, nil
+}
+
+func paretoResult(objectives []flowshop.Objective, archive *opt.Archive, evals, gens int, start time.Time, meta map[string]any) opt.ParetoResult {
+	front := make([]opt.ParetoSolution, len(archive.Solutions))
+	copy(front, archive.Solutions)
+	sort.Slice(front, func(i, j int) bool {
+		a, b := front[i].Objectives, front[j].Objectives
+		for k := range a {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return false
+	})
+	return opt.ParetoResult{
+		Objectives:  append([]flowshop.Objective(nil), objectives...),
+		Front:       front,
+		Evaluations: evals,
+		Iterations:  gens,
+		Duration:    time.Since(start),
+		Meta:        meta,
+	}
+}
+
+// frontSorter — быстрая недоминируемая сортировка с переиспользуемыми буферами.
+type frontSorter struct {
+	domCount  []int
+	dominates [][]int
+}
+
+func newFrontSorter(capacity int) *frontSorter {
+	return &frontSorter{
+		domCount:  make([]int, capacity),
+		dominates: make([][]int, capacity),
+	}
+}
+
+// sort разбивает решения на фронты и записывает ранг (0 — первый фронт) в rank.
+func (fs *frontSorter) sort(objs [][]int, rank []int) [][]int {
+	n := len(objs)
+	for i := 0; i < n; i++ {
+		fs.domCount[i] = 0
+		fs.dominates[i] = fs.dominates[i][:0]
+	}
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			switch {
+			case opt.Dominates(objs[i], objs[j]):
+				fs.dominates[i] = append(fs.dominates[i], j)
+				fs.domCount[j]++
+			case opt.Dominates(objs[j], objs[i]):
+				fs.dominates[j] = append(fs.dominates[j], i)
+				fs.domCount[i]++
+			}
+		}
+	}
+
+	var fronts [][]int
+	var current []int
+	for i := 0; i < n; i++ {
+		if fs.domCount[i] == 0 {
+			rank[i] = 0
+			current = append(current, i)
+		}
+	}
+	for r := 0; len(current) > 0; r++ {
+		fronts = append(fronts, current)
+		var nextFront []int
+		for _, i := range current {
+			for _, j := range fs.dominates[i] {
+				fs.domCount[j]--
+				if fs.domCount[j] == 0 {
+					rank[j] = r + 1
+					nextFront = append(nextFront, j)
+				}
+			}
+		}
+		current = nextFront
+	}
+	return fronts
+}
+
+// crowdingDistance вычисляет расстояние скученности для решений одного фронта.
+// Крайние решения по каждому критерию получают +Inf.
+func crowdingDistance(front []int, objs [][]int, crowd []float64) {
+	for _, i := range front {
+		crowd[i] = 0
+	}
+	if len(front) <= 2 {
+		for _, i := range front {
+			crowd[i] = math.Inf(1)
+		}
+		return
+	}
+	order := make([]int, len(front))
+	nObj := len(objs[front[0]])
+	for k := 0; k < nObj; k++ {
+		copy(order, front)
+		sort.Slice(order, func(a, b int) bool {
+			return objs[order[a]][k] < objs[order[b]][k]
+		})
+		lo := objs[order[0]][k]
+		hi := objs[order[len(order)-1]][k]
+		crowd[order[0]] = math.Inf(1)
+		crowd[order[len(order)-1]] = math.Inf(1)
+		if hi == lo {
+			continue
+		}
+		span := float64(hi - lo)
+		for i := 1; i < len(order)-1; i++ {
+			crowd[order[i]] += float64(objs[order[i+1]][k]-objs[order[i-1]][k]) / span
+		}
+	}
+}
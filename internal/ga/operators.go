@@ -1,33 +1,27 @@
 package ga
 
-import "math/rand"
+import (
+	"math/rand"
 
-// initPermutation генерирует срез [0, 1, 2, ..., n-1].
-// Используется как базовое состояние перед случайной перестановкой.
-func initPermutation(p []int) {
-	for i := range p {
-		p[i] = i
-	}
-}
-
-// shufflePermutation выполняет случайную перестановку элементов.
-func shufflePermutation(p []int, rng *rand.Rand) {
-	for i := len(p) - 1; i > 0; i-- {
-		j := rng.Intn(i + 1)
-		p[i], p[j] = p[j], p[i]
-	}
-}
+	"flowShop/internal/ls"
+)
 
 // tournamentSelect реализует турнирный отбор.
 // возвращается индекс особи с наилучшим значением fitness (минимальное значение целевой функции).
 func tournamentSelect(scores []int, tournamentSize int, rng *rand.Rand) int {
-	best := rng.Intn(len(scores))
-	bestScore := scores[best]
+	return tournamentSelectFunc(len(scores), tournamentSize, rng, func(a, b int) bool {
+		return scores[a] < scores[b]
+	})
+}
+
+// tournamentSelectFunc — турнирный отбор с произвольным отношением "лучше" среди n особей.
+// better(a, b) сообщает, что особь a строго лучше особи b.
+func tournamentSelectFunc(n, tournamentSize int, rng *rand.Rand, better func(a, b int) bool) int {
+	best := rng.Intn(n)
 	for i := 1; i < tournamentSize; i++ {
-		cand := rng.Intn(len(scores))
-		if scores[cand] < bestScore {
+		cand := rng.Intn(n)
+		if better(cand, best) {
 			best = cand
-			bestScore = scores[cand]
 		}
 	}
 	return best
@@ -125,5 +119,5 @@ func mutateSwap(p []int, rng *rand.Rand) {
 	if j >= i {
 		j++
 	}
-	p[i], p[j] = p[j], p[i]
+	ls.ApplySwap(p, i, j)
 }
@@ -0,0 +1,32 @@
+package ga
+
+// Replacement — политика формирования следующего поколения.
+type Replacement string
+
+const (
+	// ReplacementGenerational — поколенческая замена с переносом Elite лучших особей.
+	ReplacementGenerational Replacement = "generational"
+	// ReplacementSteadyState — стационарная схема: каждый потомок вытесняет худшую особь,
+	// если он лучше неё и не совпадает ни с одной особью популяции.
+	ReplacementSteadyState Replacement = "steady-state"
+	// ReplacementMuPlusLambda — (μ+λ): из родителей и потомков отбираются μ лучших.
+	ReplacementMuPlusLambda Replacement = "mu-plus-lambda"
+)
+
+func validReplacement(r Replacement) bool {
+	switch r {
+	case ReplacementGenerational, ReplacementSteadyState, ReplacementMuPlusLambda:
+		return true
+	}
+	return false
+}
+
+// permHash — хэш перестановки (FNV-1a) для отсева дубликатов.
+func permHash(p []int) uint64 {
+	h := uint64(14695981039346656037)
+	for _, v := range p {
+		h ^= uint64(v)
+		h *= 1099511628211
+	}
+	return h
+}
@@ -0,0 +1,166 @@
+package ga
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// Selection — схема отбора родителей.
+type Selection string
+
+const (
+	// SelectionTournament — турнирный отбор размера TournamentSize.
+	SelectionTournament Selection = "tournament"
+	// SelectionRoulette — рулетка по инвертированному makespan (худший − текущий + 1).
+	SelectionRoulette Selection = "roulette"
+	// SelectionRank — линейный ранговый отбор (вес лучшей особи N, худшей — 1).
+	SelectionRank Selection = "rank"
+	// SelectionSUS — стохастическая универсальная выборка по весам рулетки.
+	SelectionSUS Selection = "sus"
+	// SelectionTruncation — равновероятный выбор среди доли TruncationRate лучших особей.
+	SelectionTruncation Selection = "truncation"
+)
+
+func validSelection(s Selection) bool {
+	switch s {
+	case SelectionTournament, SelectionRoulette, SelectionRank, SelectionSUS, SelectionTruncation:
+		return true
+	}
+	return false
+}
+
+// selector выбирает родителей из популяции с приспособленностями scores.
+// prepare вызывается при каждом изменении популяции, pick — для каждого родителя.
+type selector struct {
+	method     Selection
+	tournament int
+	truncation float64
+
+	scores []int
+	order  []int     // индексы особей по возрастанию makespan
+	cum    []float64 // накопленные веса (рулетка, ранговый отбор, SUS)
+	pool   []int     // заранее выбранные родители SUS
+	next   int
+}
+
+func newSelector(cfg Config) *selector {
+	return &selector{
+		method:     cfg.Selection,
+		tournament: cfg.TournamentSize,
+		truncation: cfg.TruncationRate,
+	}
+}
+
+// prepare готовит отбор count родителей из популяции scores.
+func (sel *selector) prepare(scores []int, count int, rng *rand.Rand) {
+	sel.scores = scores
+	n := len(scores)
+
+	switch sel.method {
+	case SelectionTournament:
+		return
+	case SelectionRank, SelectionTruncation:
+		sel.sortOrder()
+	}
+
+	if sel.method == SelectionTruncation {
+		return
+	}
+
+	sel.cum = resizeFloats(sel.cum, n)
+	acc := 0.0
+	switch sel.method {
+	case SelectionRank:
+		// order[r] получает вес n-r
+		for i := range sel.cum {
+			sel.cum[i] = 0
+		}
+		for r, idx := range sel.order {
+			sel.cum[idx] = float64(n - r)
+		}
+		for i := range sel.cum {
+			acc += sel.cum[i]
+			sel.cum[i] = acc
+		}
+	default:
+		worst := scores[0]
+		for _, v := range scores {
+			if v > worst {
+				worst = v
+			}
+		}
+		for i, v := range scores {
+			acc += float64(worst - v + 1)
+			sel.cum[i] = acc
+		}
+	}
+
+	if sel.method == SelectionSUS {
+		sel.pool = sel.pool[:0]
+		step := acc / float64(count)
+		pointer := rng.Float64() * step
+		i := 0
+		for k := 0; k < count; k++ {
+			for i < n-1 && sel.cum[i] < pointer {
+				i++
+			}
+			sel.pool = append(sel.pool, i)
+			pointer += step
+		}
+		// Перемешивание, чтобы пары родителей не зависели от порядка в популяции
+		rng.Shuffle(len(sel.pool), func(a, b int) {
+			sel.pool[a], sel.pool[b] = sel.pool[b], sel.pool[a]
+		})
+		sel.next = 0
+	}
+}
+
+// pick возвращает индекс очередного родителя.
+func (sel *selector) pick(rng *rand.Rand) int {
+	switch sel.method {
+	case SelectionTournament:
+		return tournamentSelect(sel.scores, sel.tournament, rng)
+	case SelectionTruncation:
+		k := int(float64(len(sel.scores))*sel.truncation + 0.5)
+		if k < 1 {
+			k = 1
+		}
+		return sel.order[rng.Intn(k)]
+	case SelectionSUS:
+		if sel.next >= len(sel.pool) {
+			sel.next = 0
+		}
+		idx := sel.pool[sel.next]
+		sel.next++
+		return idx
+	}
+	return rouletteSelect(sel.cum, rng)
+}
+
+func (sel *selector) sortOrder() {
+	n := len(sel.scores)
+	sel.order = sel.order[:0]
+	for i := 0; i < n; i++ {
+		sel.order = append(sel.order, i)
+	}
+	sort.SliceStable(sel.order, func(a, b int) bool {
+		return sel.scores[sel.order[a]] < sel.scores[sel.order[b]]
+	})
+}
+
+// rouletteSelect выбирает индекс пропорционально весам по накопленным суммам cum.
+func rouletteSelect(cum []float64, rng *rand.Rand) int {
+	r := rng.Float64() * cum[len(cum)-1]
+	i := sort.SearchFloat64s(cum, r)
+	if i >= len(cum) {
+		i = len(cum) - 1
+	}
+	return i
+}
+
+func resizeFloats(buf []float64, n int) []float64 {
+	if cap(buf) < n {
+		return make([]float64, n)
+	}
+	return buf[:n]
+}
@@ -0,0 +1,113 @@
+package heur
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"flowShop/internal/flowshop"
+	"flowShop/internal/opt"
+)
+
+// FRB — эвристики Рада–Руиса–Боруджердяна FRB1–FRB5 (2009): NEH,
+// в котором после каждой вставки частичная последовательность улучшается
+// переустановкой работ (извлечение и вставка в лучшую позицию с ускорением Тайара).
+// Переустановка выполняется только при строгом уменьшении makespan;
+// лучшая позиция при равенстве — самая ранняя. Варианты различаются тем,
+// какие работы переустанавливаются после вставки работы на позицию p:
+//
+//	FRB1 — все работы частичной последовательности, один проход в порядке NEH;
+//	FRB2 — все работы, кроме только что вставленной, один проход слева направо по позициям;
+//	FRB3 — все работы в порядке NEH, проходы до локального оптимума;
+//	FRB4 — работы с позиций [p−Window, p+Window], один проход;
+//	FRB5 — работы с позиций [p−Window, p+Window], проходы до локального оптимума.
+type FRB struct {
+	Variant int
+	Window  int
+}
+
+// NewFRB создаёт эвристику FRB{variant}; window используется в FRB4 и FRB5.
+func NewFRB(variant, window int) (*FRB, error) {
+	h := &FRB{Variant: variant, Window: window}
+	if err := h.validate(); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func (h *FRB) validate() error {
+	if h.Variant < 1 || h.Variant > 5 {
+		return fmt.Errorf("вариант FRB должен быть в диапазоне [1,5] (получено %d)", h.Variant)
+	}
+	if (h.Variant == 4 || h.Variant == 5) && h.Window <= 0 {
+		return fmt.Errorf("окно FRB%d должно быть > 0 (получено %d)", h.Variant, h.Window)
+	}
+	return nil
+}
+
+func (h *FRB) Solve(ctx context.Context, inst *flowshop.Instance) (opt.Result, error) {
+	start := time.Now()
+
+	if err := h.validate(); err != nil {
+		return opt.Result{}, err
+	}
+	eval, err := prepare(ctx, inst)
+	if err != nil {
+		return opt.Result{}, err
+	}
+	r, err := newReinserter(inst)
+	if err != nil {
+		return opt.Result{}, err
+	}
+
+	sums := totalTimes(inst)
+	neg := make([]int, len(sums))
+	for j, v := range sums {
+		neg[j] = -v
+	}
+	order := orderBy(neg)
+
+	seq := make([]int, 0, inst.Jobs)
+	window := make([]int, 0, inst.Jobs)
+	for step, job := range order {
+		if err := ctx.Err(); err != nil {
+			return opt.Result{}, err
+		}
+		var cost, pos int
+		seq, cost, pos = r.insert(seq, job)
+		inserted := order[:step+1]
+
+		switch h.Variant {
+		case 1:
+			r.reinsertJobs(seq, inserted, cost)
+		case 2:
+			window = window[:0]
+			for _, j := range seq {
+				if j != job {
+					window = append(window, j)
+				}
+			}
+			r.reinsertJobs(seq, window, cost)
+		case 3:
+			if _, err := r.localOptimum(ctx, seq, inserted, cost); err != nil {
+				return opt.Result{}, err
+			}
+		case 4, 5:
+			window = window[:0]
+			for i := max(0, pos-h.Window); i <= min(len(seq)-1, pos+h.Window); i++ {
+				window = append(window, seq[i])
+			}
+			if h.Variant == 4 {
+				r.reinsertJobs(seq, window, cost)
+			} else if _, err := r.localOptimum(ctx, seq, window, cost); err != nil {
+				return opt.Result{}, err
+			}
+		}
+	}
+
+	meta := map[string]any{"variant": h.Variant}
+	if h.Variant >= 4 {
+		meta["window"] = h.Window
+	}
+	return result(eval, seq, r.evals, start, meta), nil
+}
@@ -0,0 +1,137 @@
+// Package heur содержит детерминированные конструктивные эвристики для PFSP.
+// Все эвристики реализуют opt.Optimizer и при одинаковом экземпляре
+// всегда возвращают одну и ту же перестановку.
+package heur
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"flowShop/internal/flowshop"
+	"flowShop/internal/opt"
+)
+
+// totalTimes возвращает суммарное время обработки каждой работы.
+func totalTimes(inst *flowshop.Instance) []int {
+	sums := make([]int, inst.Jobs)
+	for j := 0; j < inst.Jobs; j++ {
+		for m := 0; m < inst.Machines; m++ {
+			sums[j] += inst.Time(j, m)
+		}
+	}
+	return sums
+}
+
+// orderBy возвращает работы, упорядоченные по возрастанию key;
+// при равенстве ключей работы идут в порядке возрастания номера.
+func orderBy[K int | float64](key []K) []int {
+	order := make([]int, len(key))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return key[order[a]] < key[order[b]]
+	})
+	return order
+}
+
+// result формирует opt.Result по готовой перестановке.
+func result(eval *flowshop.Evaluator, perm []int, evals int, start time.Time, meta map[string]any) opt.Result {
+	return opt.Result{
+		Permutation: perm,
+		Makespan:    eval.MustMakespan(perm),
+		Evaluations: evals + 1,
+		Iterations:  1,
+		Duration:    time.Since(start),
+		Meta:        meta,
+	}
+}
+
+// prepare проверяет экземпляр и контекст и создаёт оценщик.
+func prepare(ctx context.Context, inst *flowshop.Instance) (*flowshop.Evaluator, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return flowshop.NewEvaluator(inst)
+}
+
+// reinserter извлекает работу из частичной последовательности и вставляет её
+// в лучшую позицию (ускорение Тайара). Используется в RZ и FRB.
+type reinserter struct {
+	ins     *flowshop.InsertionEvaluator
+	scratch []int
+	evals   int
+}
+
+func newReinserter(inst *flowshop.Instance) (*reinserter, error) {
+	ins, err := flowshop.NewInsertionEvaluator(inst)
+	if err != nil {
+		return nil, err
+	}
+	return &reinserter{ins: ins, scratch: make([]int, inst.Jobs+1)}, nil
+}
+
+// insert вставляет job в лучшую позицию seq (при равенстве — самую раннюю).
+func (r *reinserter) insert(seq []int, job int) ([]int, int, int) {
+	pos, cost := r.ins.BestInsertion(seq, job, r.scratch)
+	r.evals += len(seq) + 1
+	return flowshop.Insert(seq, pos, job), cost, pos
+}
+
+// reinsert переносит работу с позиции pos в лучшую позицию, если это строго
+// уменьшает makespan cost; иначе работа остаётся на месте.
+// Возвращает новый makespan и итоговую позицию работы.
+func (r *reinserter) reinsert(seq []int, pos, cost int) (int, int) {
+	job := seq[pos]
+	copy(seq[pos:], seq[pos+1:])
+	rest := seq[:len(seq)-1]
+
+	best, ms := r.ins.BestInsertion(rest, job, r.scratch)
+	r.evals += len(rest) + 1
+	if ms >= cost {
+		best, ms = pos, cost
+	}
+	copy(seq[best+1:], rest[best:])
+	seq[best] = job
+	return ms, best
+}
+
+// reinsertJobs выполняет один проход переустановки для работ jobs (в заданном порядке;
+// работы, отсутствующие в seq, пропускаются). Возвращает makespan после прохода.
+func (r *reinserter) reinsertJobs(seq, jobs []int, cost int) int {
+	for _, job := range jobs {
+		pos := indexOf(seq, job)
+		if pos < 0 {
+			continue
+		}
+		cost, _ = r.reinsert(seq, pos, cost)
+	}
+	return cost
+}
+
+// localOptimum повторяет проходы reinsertJobs, пока makespan уменьшается.
+func (r *reinserter) localOptimum(ctx context.Context, seq, jobs []int, cost int) (int, error) {
+	for {
+		if err := ctx.Err(); err != nil {
+			return cost, err
+		}
+		next := r.reinsertJobs(seq, jobs, cost)
+		if next >= cost {
+			return next, nil
+		}
+		cost = next
+	}
+}
+
+func indexOf(seq []int, job int) int {
+	for i, v := range seq {
+		if v == job {
+			return i
+		}
+	}
+	return -1
+}
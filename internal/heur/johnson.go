@@ -0,0 +1,93 @@
+package heur
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"flowShop/internal/flowshop"
+	"flowShop/internal/opt"
+)
+
+// Johnson — точный алгоритм Джонсона для F2||Cmax, O(n log n).
+// Применим только к классическому flow-shop с двумя станками,
+// иначе возвращает ошибку, обёртывающую opt.ErrUnsupported.
+// Порядок при равенстве ключей — см. flowshop.JohnsonOrder.
+type Johnson struct{}
+
+func NewJohnson() *Johnson {
+	return &Johnson{}
+}
+
+func (h *Johnson) Solve(ctx context.Context, inst *flowshop.Instance) (opt.Result, error) {
+	start := time.Now()
+
+	eval, err := prepare(ctx, inst)
+	if err != nil {
+		return opt.Result{}, err
+	}
+	if inst.Machines != 2 || inst.IsHybrid() {
+		return opt.Result{}, fmt.Errorf("алгоритм Джонсона требует ровно 2 станка (получено %d): %w", inst.Machines, opt.ErrUnsupported)
+	}
+
+	a, b := surrogateTimes(inst, 1)
+	perm := flowshop.JohnsonOrder(a, b)
+	return result(eval, perm, 0, start, map[string]any{"optimal": true}), nil
+}
+
+// CDS — эвристика Кэмпбелла–Дудека–Смита (1970).
+// Строит m−1 суррогатных двухмашинных задач: на k-й шаге первый станок —
+// сумма времён на станках 0..k−1, второй — на станках m−k..m−1;
+// каждая решается правилом Джонсона, из полученных перестановок выбирается
+// лучшая по истинному makespan (при равенстве — с меньшим k).
+type CDS struct{}
+
+func NewCDS() *CDS {
+	return &CDS{}
+}
+
+func (h *CDS) Solve(ctx context.Context, inst *flowshop.Instance) (opt.Result, error) {
+	start := time.Now()
+
+	eval, err := prepare(ctx, inst)
+	if err != nil {
+		return opt.Result{}, err
+	}
+
+	var bestPerm []int
+	bestCost, bestK := 0, 0
+	evals := 0
+	for k := 1; k <= max(1, inst.Machines-1); k++ {
+		if err := ctx.Err(); err != nil {
+			return opt.Result{}, err
+		}
+		a, b := surrogateTimes(inst, k)
+		perm := flowshop.JohnsonOrder(a, b)
+		cost := eval.MustMakespan(perm)
+		evals++
+		if bestPerm == nil || cost < bestCost {
+			bestPerm, bestCost, bestK = perm, cost, k
+		}
+	}
+	return result(eval, bestPerm, evals, start, map[string]any{
+		"subproblems": max(1, inst.Machines-1),
+		"best_k":      bestK,
+	}), nil
+}
+
+// surrogateTimes возвращает времена суррогатной двухмашинной задачи CDS:
+// a_j — сумма по первым k станкам, b_j — по последним k станкам.
+func surrogateTimes(inst *flowshop.Instance, k int) ([]int, []int) {
+	m := inst.Machines
+	a := make([]int, inst.Jobs)
+	b := make([]int, inst.Jobs)
+	for j := 0; j < inst.Jobs; j++ {
+		for l := 0; l < k && l < m; l++ {
+			a[j] += inst.Time(j, l)
+		}
+		for l := max(0, m-k); l < m; l++ {
+			b[j] += inst.Time(j, l)
+		}
+	}
+	return a, b
+}
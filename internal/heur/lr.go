@@ -0,0 +1,184 @@
+package heur
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"flowShop/internal/flowshop"
+	"flowShop/internal/opt"
+)
+
+// LR — эвристика Лю–Ривза LR(x) (2001).
+// Работа, добавляемая в конец частичной последовательности S (|S| = k),
+// выбирается по минимуму индекса ξ_j = (n − k − 2)·IT_j + AT_j, где
+// IT_j — взвешенный простой станков при добавлении j с весами
+// w_i = m / (i + k(m − i)/(n − 2)), а AT_j — сумма времён завершения j
+// и искусственной работы со средними временами оставшихся работ.
+// Первые x работ рейтинга для пустой S по очереди становятся первыми в
+// последовательности; каждая достраивается жадно по ξ, выбирается
+// лучшая по makespan. При равенстве индексов выбирается работа с меньшим
+// номером, при равенстве makespan — последовательность с меньшим рангом старта.
+type LR struct {
+	// X — число стартовых работ; 0 — рекомендованное авторами max(1, n/m)
+	X int
+}
+
+func NewLR(x int) (*LR, error) {
+	if x < 0 {
+		return nil, fmt.Errorf("x должно быть >= 0 (получено %d)", x)
+	}
+	return &LR{X: x}, nil
+}
+
+func (h *LR) Solve(ctx context.Context, inst *flowshop.Instance) (opt.Result, error) {
+	start := time.Now()
+
+	if h.X < 0 {
+		return opt.Result{}, fmt.Errorf("x должно быть >= 0 (получено %d)", h.X)
+	}
+	eval, err := prepare(ctx, inst)
+	if err != nil {
+		return opt.Result{}, err
+	}
+
+	b := newLRBuilder(inst)
+	b.reset()
+	xi := make([]float64, inst.Jobs)
+	for j := range xi {
+		xi[j] = b.index(j)
+	}
+	ranking := orderBy(xi)
+
+	x := h.X
+	if x == 0 {
+		x = max(1, inst.Jobs/inst.Machines)
+	}
+	x = min(x, inst.Jobs)
+	var bestPerm []int
+	bestCost := 0
+	for r := 0; r < x; r++ {
+		if err := ctx.Err(); err != nil {
+			return opt.Result{}, err
+		}
+		perm := b.build(ranking[r])
+		cost := eval.MustMakespan(perm)
+		if bestPerm == nil || cost < bestCost {
+			bestPerm, bestCost = perm, cost
+		}
+	}
+	return result(eval, bestPerm, x, start, map[string]any{"x": x}), nil
+}
+
+// lrBuilder хранит состояние жадного построения LR.
+type lrBuilder struct {
+	inst *flowshop.Instance
+	n, m int
+
+	seq       []int
+	scheduled []bool
+	comp      []int     // завершение S на станках
+	sumU      []int     // нагрузка станков по незапланированным работам
+	cj        []int     // завершение кандидата
+	cp        []float64 // завершение искусственной работы
+}
+
+func newLRBuilder(inst *flowshop.Instance) *lrBuilder {
+	n, m := inst.Jobs, inst.Machines
+	return &lrBuilder{
+		inst:      inst,
+		n:         n,
+		m:         m,
+		seq:       make([]int, 0, n),
+		scheduled: make([]bool, n),
+		comp:      make([]int, m),
+		sumU:      make([]int, m),
+		cj:        make([]int, m),
+		cp:        make([]float64, m),
+	}
+}
+
+func (b *lrBuilder) reset() {
+	b.seq = b.seq[:0]
+	for j := range b.scheduled {
+		b.scheduled[j] = false
+	}
+	for i := 0; i < b.m; i++ {
+		b.comp[i] = 0
+		b.sumU[i] = 0
+		for j := 0; j < b.n; j++ {
+			b.sumU[i] += b.inst.Time(j, i)
+		}
+	}
+}
+
+// index вычисляет ξ_j для добавления работы j в конец текущей последовательности.
+func (b *lrBuilder) index(j int) float64 {
+	inst := b.inst
+	k := len(b.seq)
+	rem := b.n - k // незапланированных, включая j
+
+	b.cj[0] = b.comp[0] + inst.Time(j, 0)
+	for i := 1; i < b.m; i++ {
+		b.cj[i] = max(b.cj[i-1], b.comp[i]) + inst.Time(j, i)
+	}
+
+	// Взвешенный простой; i1 — номер станка с единицы
+	it := 0.0
+	for i := 1; i < b.m; i++ {
+		i1 := float64(i + 1)
+		w := float64(b.m) / i1
+		if b.n > 2 {
+			w = float64(b.m) / (i1 + float64(k)*(float64(b.m)-i1)/float64(b.n-2))
+		}
+		if idle := b.cj[i-1] - b.comp[i]; idle > 0 {
+			it += w * float64(idle)
+		}
+	}
+
+	// Искусственная работа со средними временами остальных незапланированных работ
+	at := float64(b.cj[b.m-1])
+	if rem > 1 {
+		prev := 0.0
+		for i := 0; i < b.m; i++ {
+			q := float64(b.sumU[i]-inst.Time(j, i)) / float64(rem-1)
+			b.cp[i] = math.Max(prev, float64(b.cj[i])) + q
+			prev = b.cp[i]
+		}
+		at += b.cp[b.m-1]
+	}
+	return float64(b.n-k-2)*it + at
+}
+
+// append добавляет работу j в конец последовательности.
+func (b *lrBuilder) append(j int) {
+	inst := b.inst
+	b.seq = append(b.seq, j)
+	b.scheduled[j] = true
+	b.comp[0] += inst.Time(j, 0)
+	b.sumU[0] -= inst.Time(j, 0)
+	for i := 1; i < b.m; i++ {
+		b.comp[i] = max(b.comp[i-1], b.comp[i]) + inst.Time(j, i)
+		b.sumU[i] -= inst.Time(j, i)
+	}
+}
+
+// build строит последовательность, начинающуюся с first, и возвращает её копию.
+func (b *lrBuilder) build(first int) []int {
+	b.reset()
+	b.append(first)
+	for len(b.seq) < b.n {
+		best, bestXi := -1, 0.0
+		for j := 0; j < b.n; j++ {
+			if b.scheduled[j] {
+				continue
+			}
+			if xi := b.index(j); best < 0 || xi < bestXi {
+				best, bestXi = j, xi
+			}
+		}
+		b.append(best)
+	}
+	return append([]int(nil), b.seq...)
+}
@@ -0,0 +1,66 @@
+package heur
+
+import (
+	"context"
+	"time"
+
+	"flowShop/internal/flowshop"
+	"flowShop/internal/opt"
+)
+
+// NEH — эвристика Наваза–Энскора–Хэма (1983).
+// Работы упорядочиваются по убыванию суммарного времени обработки
+// (при равенстве — по возрастанию номера) и по одной вставляются
+// в позицию частичной последовательности с минимальным makespan
+// (при равенстве — в самую раннюю позицию). Оценка вставок — с ускорением Тайара.
+type NEH struct{}
+
+func NewNEH() *NEH {
+	return &NEH{}
+}
+
+func (h *NEH) Solve(ctx context.Context, inst *flowshop.Instance) (opt.Result, error) {
+	start := time.Now()
+
+	eval, err := prepare(ctx, inst)
+	if err != nil {
+		return opt.Result{}, err
+	}
+	perm, evals, err := NEHSequence(ctx, inst)
+	if err != nil {
+		return opt.Result{}, err
+	}
+	return result(eval, perm, evals, start, map[string]any{}), nil
+}
+
+// NEHSequence строит перестановку NEH и возвращает её вместе с числом
+// оценённых вставок (по одной на каждую рассмотренную позицию).
+func NEHSequence(ctx context.Context, inst *flowshop.Instance) ([]int, int, error) {
+	sums := totalTimes(inst)
+	neg := make([]int, len(sums))
+	for j, v := range sums {
+		neg[j] = -v
+	}
+	return insertionOrder(ctx, inst, orderBy(neg))
+}
+
+// insertionOrder выполняет вставочную фазу NEH для заданного порядка работ.
+func insertionOrder(ctx context.Context, inst *flowshop.Instance, order []int) ([]int, int, error) {
+	ins, err := flowshop.NewInsertionEvaluator(inst)
+	if err != nil {
+		return nil, 0, err
+	}
+	n := inst.Jobs
+	seq := make([]int, 0, n)
+	scratch := make([]int, n+1)
+	evals := 0
+	for _, job := range order {
+		if err := ctx.Err(); err != nil {
+			return nil, evals, err
+		}
+		pos, _ := ins.BestInsertion(seq, job, scratch)
+		evals += len(seq) + 1
+		seq = flowshop.Insert(seq, pos, job)
+	}
+	return seq, evals, nil
+}
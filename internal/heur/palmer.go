@@ -0,0 +1,73 @@
+package heur
+
+import (
+	"context"
+	"time"
+
+	"flowShop/internal/flowshop"
+	"flowShop/internal/opt"
+)
+
+// Palmer — эвристика наклона Палмера (1965).
+// Индекс работы s_j = Σ_k (2k − m − 1)·p_jk (k = 1..m); работы упорядочиваются
+// по убыванию s_j, при равенстве — по возрастанию номера.
+type Palmer struct{}
+
+func NewPalmer() *Palmer {
+	return &Palmer{}
+}
+
+func (h *Palmer) Solve(ctx context.Context, inst *flowshop.Instance) (opt.Result, error) {
+	start := time.Now()
+
+	eval, err := prepare(ctx, inst)
+	if err != nil {
+		return opt.Result{}, err
+	}
+	m := inst.Machines
+	key := make([]int, inst.Jobs)
+	for j := range key {
+		slope := 0
+		for k := 1; k <= m; k++ {
+			slope += (2*k - m - 1) * inst.Time(j, k-1)
+		}
+		key[j] = -slope
+	}
+	return result(eval, orderBy(key), 0, start, map[string]any{}), nil
+}
+
+// Gupta — эвристика Гупты (1971).
+// s_j = e_j / min_k (p_jk + p_j,k+1), где e_j = 1 при p_j1 < p_jm и −1 иначе;
+// работы упорядочиваются по убыванию s_j, при равенстве — по возрастанию номера.
+// При m = 1 знаменатель равен p_j1; нулевой знаменатель даёт ±∞.
+type Gupta struct{}
+
+func NewGupta() *Gupta {
+	return &Gupta{}
+}
+
+func (h *Gupta) Solve(ctx context.Context, inst *flowshop.Instance) (opt.Result, error) {
+	start := time.Now()
+
+	eval, err := prepare(ctx, inst)
+	if err != nil {
+		return opt.Result{}, err
+	}
+	m := inst.Machines
+	key := make([]float64, inst.Jobs)
+	for j := range key {
+		e := -1.0
+		if inst.Time(j, 0) < inst.Time(j, m-1) {
+			e = 1.0
+		}
+		denom := inst.Time(j, 0)
+		for k := 0; k+1 < m; k++ {
+			v := inst.Time(j, k) + inst.Time(j, k+1)
+			if k == 0 || v < denom {
+				denom = v
+			}
+		}
+		key[j] = -e / float64(denom)
+	}
+	return result(eval, orderBy(key), 0, start, map[string]any{}), nil
+}
@@ -0,0 +1,49 @@
+package heur
+
+import (
+	"context"
+	"time"
+
+	"flowShop/internal/flowshop"
+	"flowShop/internal/opt"
+)
+
+// RZ — эвристика Раджендрана–Циглера (1997).
+// Начальный порядок — по возрастанию взвешенной суммы Σ_k (m − k + 1)·p_jk
+// (при равенстве — по возрастанию номера). Затем работы в этом порядке
+// поочерёдно извлекаются и вставляются в лучшую позицию; перенос выполняется
+// только при строгом уменьшении makespan (при равных позициях — самая ранняя).
+// Проходы повторяются, пока makespan уменьшается.
+type RZ struct{}
+
+func NewRZ() *RZ {
+	return &RZ{}
+}
+
+func (h *RZ) Solve(ctx context.Context, inst *flowshop.Instance) (opt.Result, error) {
+	start := time.Now()
+
+	eval, err := prepare(ctx, inst)
+	if err != nil {
+		return opt.Result{}, err
+	}
+	m := inst.Machines
+	key := make([]int, inst.Jobs)
+	for j := range key {
+		for k := 1; k <= m; k++ {
+			key[j] += (m - k + 1) * inst.Time(j, k-1)
+		}
+	}
+	seed := orderBy(key)
+
+	r, err := newReinserter(inst)
+	if err != nil {
+		return opt.Result{}, err
+	}
+	perm := append([]int(nil), seed...)
+	cost := eval.MustMakespan(perm)
+	if _, err := r.localOptimum(ctx, perm, seed, cost); err != nil {
+		return opt.Result{}, err
+	}
+	return result(eval, perm, r.evals+1, start, map[string]any{}), nil
+}
@@ -0,0 +1,83 @@
+package ils
+
+import "fmt"
+
+// Acceptance — критерий принятия решения после возмущения и локального поиска.
+type Acceptance string
+
+const (
+	// AcceptBetter — принимается только строго лучшее решение.
+	AcceptBetter Acceptance = "better"
+	// AcceptRandomWalk — принимается любое новое решение.
+	AcceptRandomWalk Acceptance = "random-walk"
+	// AcceptRestart — как better, но после RestartAfter итераций без улучшения
+	// лучшего решения поиск перезапускается со случайной перестановки.
+	AcceptRestart Acceptance = "restart"
+)
+
+type Config struct {
+	Iterations       int
+	IterationsPerJob int
+
+	// PerturbationStrength — число случайных insert-ходов при возмущении
+	PerturbationStrength int
+
+	Acceptance Acceptance
+
+	// RestartAfter — число итераций без улучшения до перезапуска (для restart)
+	RestartAfter int
+
+	// LocalSearchTries — число подряд неудачных случайных insert-ходов,
+	// после которого локальный спуск останавливается
+	LocalSearchTries int
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Iterations:       0,
+		IterationsPerJob: 60,
+
+		PerturbationStrength: 3,
+		Acceptance:           AcceptBetter,
+		RestartAfter:         200,
+
+		LocalSearchTries: 100,
+	}
+}
+
+func (c Config) Validate() error {
+	if c.Iterations <= 0 && c.IterationsPerJob <= 0 {
+		return fmt.Errorf(
+			"должно быть задано Iterations > 0 или IterationsPerJob > 0",
+		)
+	}
+	if c.PerturbationStrength <= 0 {
+		return fmt.Errorf(
+			"PerturbationStrength должно быть > 0 (получено %d)",
+			c.PerturbationStrength,
+		)
+	}
+	switch c.Acceptance {
+	case AcceptBetter, AcceptRandomWalk:
+		// ok
+	case AcceptRestart:
+		if c.RestartAfter <= 0 {
+			return fmt.Errorf(
+				"RestartAfter должно быть > 0 (получено %d)",
+				c.RestartAfter,
+			)
+		}
+	default:
+		return fmt.Errorf(
+			"неизвестный критерий принятия %q",
+			c.Acceptance,
+		)
+	}
+	if c.LocalSearchTries <= 0 {
+		return fmt.Errorf(
+			"LocalSearchTries должно быть > 0 (получено %d)",
+			c.LocalSearchTries,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package ils
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"flowShop/internal/flowshop"
+	"flowShop/internal/ls"
+	"flowShop/internal/opt"
+)
+
+// Solver — реализация итерированного локального поиска (ILS).
+type Solver struct {
+	Cfg Config
+	Rng *rand.Rand
+}
+
+// New возвращает новый ILS-солвер с валидацией конфигурации, с использованием инициализированного генератора случайных чисел.
+// Используется в фабриках.
+func New(cfg Config, rng *rand.Rand) (*Solver, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if rng == nil {
+		return nil, fmt.Errorf("генератор случайных чисел не инициализирован (nil)")
+	}
+	return &Solver{Cfg: cfg, Rng: rng}, nil
+}
+
+// Solve — основной цикл: возмущение текущего решения, локальный спуск,
+// решение о принятии по критерию Acceptance.
+func (s *Solver) Solve(ctx context.Context, inst *flowshop.Instance) (opt.Result, error) {
+	start := time.Now()
+
+	if err := inst.Validate(); err != nil {
+		return opt.Result{}, err
+	}
+	if err := s.Cfg.Validate(); err != nil {
+		return opt.Result{}, err
+	}
+	if s.Rng == nil {
+		return opt.Result{}, fmt.Errorf("генератор случайных чисел не инициализирован (nil)")
+	}
+
+	eval, err := flowshop.NewEvaluator(inst)
+	if err != nil {
+		return opt.Result{}, err
+	}
+
+	n := inst.Jobs
+
+	maxIter := s.Cfg.Iterations
+	if maxIter <= 0 {
+		maxIter = s.Cfg.IterationsPerJob * n
+	}
+
+	curr := make([]int, n)
+	next := make([]int, n)
+	cand := make([]int, n)
+	best := make([]int, n)
+
+	ls.InitPermutation(curr)
+	ls.ShufflePermutation(curr, s.Rng)
+	currCost := eval.MustMakespan(curr)
+	evals := 1
+	currCost, lsEvals := s.localSearch(eval, curr, cand, currCost)
+	evals += lsEvals
+
+	copy(best, curr)
+	bestCost := currCost
+
+	restarts := 0
+	sinceImprove := 0
+
+	for iter := 0; iter < maxIter; iter++ {
+		// Для поддержки отмены через context
+		if err := ctx.Err(); err != nil {
+			return opt.Result{
+				Permutation: best,
+				Makespan:    bestCost,
+				Evaluations: evals,
+				Iterations:  iter,
+				Duration:    time.Since(start),
+				Meta: map[string]any{
+					"stopped":  "context",
+					"restarts": restarts,
+				},
+			}, err
+		}
+
+		// Возмущение
+		copy(next, curr)
+		for i := 0; i < s.Cfg.PerturbationStrength; i++ {
+			randomInsert(next, s.Rng)
+		}
+		nextCost := eval.MustMakespan(next)
+		evals++
+
+		nextCost, lsEvals = s.localSearch(eval, next, cand, nextCost)
+		evals += lsEvals
+
+		// Критерий принятия
+		switch s.Cfg.Acceptance {
+		case AcceptRandomWalk:
+			curr, next = next, curr
+			currCost = nextCost
+		default:
+			if nextCost < currCost {
+				curr, next = next, curr
+				currCost = nextCost
+			}
+		}
+
+		if currCost < bestCost {
+			bestCost = currCost
+			copy(best, curr)
+			sinceImprove = 0
+		} else {
+			sinceImprove++
+		}
+
+		// Перезапуск со случайного решения при застое
+		if s.Cfg.Acceptance == AcceptRestart && sinceImprove >= s.Cfg.RestartAfter {
+			ls.ShufflePermutation(curr, s.Rng)
+			currCost = eval.MustMakespan(curr)
+			evals++
+			currCost, lsEvals = s.localSearch(eval, curr, cand, currCost)
+			evals += lsEvals
+			if currCost < bestCost {
+				bestCost = currCost
+				copy(best, curr)
+			}
+			restarts++
+			sinceImprove = 0
+		}
+	}
+
+	return opt.Result{
+		Permutation: best,
+		Makespan:    bestCost,
+		Evaluations: evals,
+		Iterations:  maxIter,
+		Duration:    time.Since(start),
+		Meta: map[string]any{
+			"perturbation_strength": s.Cfg.PerturbationStrength,
+			"acceptance":            string(s.Cfg.Acceptance),
+			"restarts":              restarts,
+		},
+	}, nil
+}
+
+// localSearch — спуск с первым улучшением по случайным insert-ходам;
+// останавливается после LocalSearchTries неудачных попыток подряд.
+// p изменяется на месте; возвращает новую стоимость и число оценок.
+func (s *Solver) localSearch(eval *flowshop.Evaluator, p, cand []int, cost int) (int, int) {
+	evals := 0
+	for fails := 0; fails < s.Cfg.LocalSearchTries; {
+		copy(cand, p)
+		randomInsert(cand, s.Rng)
+		c := eval.MustMakespan(cand)
+		evals++
+		if c < cost {
+			copy(p, cand)
+			cost = c
+			fails = 0
+		} else {
+			fails++
+		}
+	}
+	return cost, evals
+}
+
+// randomInsert переносит случайную работу в случайную другую позицию.
+func randomInsert(p []int, rng *rand.Rand) {
+	n := len(p)
+	if n < 2 {
+		return
+	}
+	from := rng.Intn(n)
+	to := rng.Intn(n - 1)
+	if to >= from {
+		to++
+	}
+	ls.ApplyInsert(p, from, to)
+}
@@ -0,0 +1,87 @@
+package ls
+
+import "fmt"
+
+// Neighborhood определяет окрестность локального спуска.
+type Neighborhood string
+
+const (
+	// NeighborhoodNone — локальный поиск отключён.
+	NeighborhoodNone Neighborhood = ""
+	// NeighborhoodInsert — извлечение работы и вставка в другую позицию.
+	NeighborhoodInsert Neighborhood = "insert"
+	// NeighborhoodSwap — обмен двух работ.
+	NeighborhoodSwap Neighborhood = "swap"
+	// NeighborhoodOrOpt — перенос блока из 1..OrOptMaxLen подряд идущих работ.
+	NeighborhoodOrOpt Neighborhood = "or-opt"
+	// NeighborhoodRZ — переустановка работ в порядке опорной последовательности
+	// (процедура Раджендрана–Циглера).
+	NeighborhoodRZ Neighborhood = "rz"
+)
+
+// Strategy — стратегия выбора улучшающего хода.
+type Strategy string
+
+const (
+	StrategyFirst Strategy = "first"
+	StrategyBest  Strategy = "best"
+)
+
+type Config struct {
+	Neighborhood Neighborhood
+	Strategy     Strategy
+
+	// MaxPasses — ограничение числа проходов по окрестности (0 — до локального оптимума)
+	MaxPasses int
+
+	// OrOptMaxLen — максимальная длина блока для окрестности or-opt
+	OrOptMaxLen int
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Neighborhood: NeighborhoodInsert,
+		Strategy:     StrategyFirst,
+		MaxPasses:    0,
+		OrOptMaxLen:  3,
+	}
+}
+
+// Enabled сообщает, включён ли локальный поиск.
+func (c Config) Enabled() bool {
+	return c.Neighborhood != NeighborhoodNone
+}
+
+func (c Config) Validate() error {
+	switch c.Neighborhood {
+	case NeighborhoodInsert, NeighborhoodSwap, NeighborhoodOrOpt, NeighborhoodRZ:
+		// ok
+	default:
+		return fmt.Errorf(
+			"неизвестный тип окрестности локального поиска %q",
+			c.Neighborhood,
+		)
+	}
+	switch c.Strategy {
+	case StrategyFirst, StrategyBest:
+		// ok
+	default:
+		return fmt.Errorf(
+			"неизвестная стратегия локального поиска %q",
+			c.Strategy,
+		)
+	}
+	if c.MaxPasses < 0 {
+		return fmt.Errorf(
+			"MaxPasses должно быть >= 0 (получено %d)",
+			c.MaxPasses,
+		)
+	}
+	if c.Neighborhood == NeighborhoodOrOpt && c.OrOptMaxLen <= 0 {
+		return fmt.Errorf(
+			"OrOptMaxLen должно быть > 0 (получено %d)",
+			c.OrOptMaxLen,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,256 @@
+// Package ls содержит общие для метаэвристик средства локального поиска:
+// операции над перестановками и детерминированные спуски по полным
+// окрестностям с инкрементальной оценкой makespan.
+package ls
+
+import (
+	"context"
+	"fmt"
+
+	"flowShop/internal/flowshop"
+)
+
+// Searcher выполняет детерминированный локальный спуск до локального оптимума
+// (или до исчерпания MaxPasses проходов).
+//
+// Оценка соседей инкрементальная: для insert и rz используется ускорение Тайара,
+// для swap и or-opt — головы и хвосты текущей перестановки, так что пересчитывается
+// только изменившийся участок. Для гибридного flow-shop головы и хвосты неприменимы,
+// и каждый сосед оценивается полным декодированием.
+//
+// Стратегии:
+//   - first — применяется первый найденный улучшающий ход, просмотр продолжается
+//     с места применения; для insert ход — лучшая вставка очередной работы;
+//   - best — просматривается вся окрестность, применяется лучший ход.
+//
+// Для rz стратегия не используется: работы переустанавливаются в порядке опорной
+// последовательности (перестановка на начало прохода).
+//
+// Searcher не безопасен для конкурентного использования.
+type Searcher struct {
+	Cfg Config
+
+	ins  *flowshop.InsertionEvaluator
+	segs *SegmentEvaluator
+
+	rest, ref, out, seg []int
+}
+
+func New(cfg Config, inst *flowshop.Instance) (*Searcher, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("некорректная конфигурация локального поиска: %w", err)
+	}
+	ins, err := flowshop.NewInsertionEvaluator(inst)
+	if err != nil {
+		return nil, err
+	}
+	segs, err := NewSegmentEvaluator(inst)
+	if err != nil {
+		return nil, err
+	}
+	n := inst.Jobs
+	return &Searcher{
+		Cfg:  cfg,
+		ins:  ins,
+		segs: segs,
+		rest: make([]int, 0, n),
+		ref:  make([]int, n),
+		out:  make([]int, n+1),
+		seg:  make([]int, 0, n),
+	}, nil
+}
+
+// Improve улучшает perm на месте; cost — её makespan.
+// Возвращает новый makespan и число оценённых соседей.
+// При отмене ctx возвращается лучшее найденное к этому моменту решение.
+func (s *Searcher) Improve(ctx context.Context, perm []int, cost int) (int, int) {
+	if len(perm) < 2 {
+		return cost, 0
+	}
+
+	evals := 0
+	for pass := 0; s.Cfg.MaxPasses == 0 || pass < s.Cfg.MaxPasses; pass++ {
+		if ctx.Err() != nil {
+			break
+		}
+		var (
+			next int
+			n    int
+		)
+		switch s.Cfg.Neighborhood {
+		case NeighborhoodInsert:
+			next, n = s.insertPass(perm, cost)
+		case NeighborhoodSwap:
+			next, n = s.swapPass(ctx, perm, cost)
+		case NeighborhoodOrOpt:
+			next, n = s.orOptPass(ctx, perm, cost)
+		case NeighborhoodRZ:
+			next, n = s.rzPass(perm, cost)
+		}
+		evals += n
+		if next >= cost {
+			break
+		}
+		cost = next
+	}
+	return cost, evals
+}
+
+// insertPass — проход по окрестности insert: каждая работа извлекается
+// и оценивается во всех позициях за O(n·m).
+func (s *Searcher) insertPass(perm []int, cost int) (int, int) {
+	n := len(perm)
+	evals := 0
+	bestFrom, bestTo, bestCost := -1, -1, cost
+
+	for i := 0; i < n; i++ {
+		pos, ms := s.bestReinsertion(perm, i)
+		evals += n
+
+		if ms >= bestCost {
+			continue
+		}
+		if s.Cfg.Strategy == StrategyFirst {
+			ApplyInsert(perm, i, pos)
+			cost = ms
+			bestCost = ms
+			continue
+		}
+		bestFrom, bestTo, bestCost = i, pos, ms
+	}
+
+	if s.Cfg.Strategy == StrategyBest && bestFrom >= 0 {
+		ApplyInsert(perm, bestFrom, bestTo)
+		return bestCost, evals
+	}
+	return cost, evals
+}
+
+// rzPass — проход RZ: работы опорной последовательности по очереди
+// переустанавливаются в лучшую позицию, если это строго улучшает makespan.
+func (s *Searcher) rzPass(perm []int, cost int) (int, int) {
+	n := len(perm)
+	copy(s.ref, perm)
+	evals := 0
+	for _, job := range s.ref[:n] {
+		i := indexOf(perm, job)
+		pos, ms := s.bestReinsertion(perm, i)
+		evals += n
+		if ms < cost {
+			ApplyInsert(perm, i, pos)
+			cost = ms
+		}
+	}
+	return cost, evals
+}
+
+// bestReinsertion извлекает работу из позиции i и возвращает лучшую позицию
+// для её вставки (при равенстве — самую раннюю) и соответствующий makespan.
+func (s *Searcher) bestReinsertion(perm []int, i int) (int, int) {
+	job := perm[i]
+	s.rest = append(s.rest[:0], perm[:i]...)
+	s.rest = append(s.rest, perm[i+1:]...)
+	out := s.out[:len(perm)]
+	s.ins.InsertionMakespans(s.rest, job, out)
+
+	pos, ms := 0, out[0]
+	for p := 1; p < len(out); p++ {
+		if out[p] < ms {
+			pos, ms = p, out[p]
+		}
+	}
+	return pos, ms
+}
+
+// swapPass — проход по окрестности swap: для пары (i, j) пересчитывается
+// только участок i..j между головой префикса и хвостом суффикса.
+func (s *Searcher) swapPass(ctx context.Context, perm []int, cost int) (int, int) {
+	n := len(perm)
+	evals := 0
+	bestI, bestJ, bestCost := -1, -1, cost
+	s.segs.Prepare(perm)
+
+	for i := 0; i < n-1; i++ {
+		if ctx.Err() != nil {
+			break
+		}
+		for j := i + 1; j < n; j++ {
+			s.seg = append(s.seg[:0], perm[i:j+1]...)
+			s.seg[0], s.seg[j-i] = s.seg[j-i], s.seg[0]
+			ms := s.segs.Makespan(perm, i, j, s.seg)
+			evals++
+
+			if ms >= bestCost {
+				continue
+			}
+			if s.Cfg.Strategy == StrategyFirst {
+				ApplySwap(perm, i, j)
+				cost, bestCost = ms, ms
+				s.segs.Prepare(perm)
+				continue
+			}
+			bestI, bestJ, bestCost = i, j, ms
+		}
+	}
+
+	if s.Cfg.Strategy == StrategyBest && bestI >= 0 {
+		ApplySwap(perm, bestI, bestJ)
+		return bestCost, evals
+	}
+	return cost, evals
+}
+
+// orOptPass — проход по окрестности or-opt: блок длины 1..OrOptMaxLen
+// переносится так, чтобы начинаться с позиции to.
+func (s *Searcher) orOptPass(ctx context.Context, perm []int, cost int) (int, int) {
+	n := len(perm)
+	evals := 0
+	bestFrom, bestLen, bestTo, bestCost := -1, 0, -1, cost
+	s.segs.Prepare(perm)
+
+scan:
+	for length := 1; length <= s.Cfg.OrOptMaxLen && length < n; length++ {
+		for from := 0; from+length <= n; from++ {
+			if ctx.Err() != nil {
+				break scan
+			}
+			for to := 0; to+length <= n; to++ {
+				if to == from {
+					continue
+				}
+				a := min(from, to)
+				b := max(from, to) + length - 1
+				s.seg = append(s.seg[:0], perm[a:b+1]...)
+				ApplyBlockMove(s.seg, from-a, length, to-a)
+				ms := s.segs.Makespan(perm, a, b, s.seg)
+				evals++
+
+				if ms >= bestCost {
+					continue
+				}
+				if s.Cfg.Strategy == StrategyFirst {
+					ApplyBlockMove(perm, from, length, to)
+					cost, bestCost = ms, ms
+					s.segs.Prepare(perm)
+					continue
+				}
+				bestFrom, bestLen, bestTo, bestCost = from, length, to, ms
+			}
+		}
+	}
+
+	if s.Cfg.Strategy == StrategyBest && bestFrom >= 0 {
+		ApplyBlockMove(perm, bestFrom, bestLen, bestTo)
+		return bestCost, evals
+	}
+	return cost, evals
+}
+
+func indexOf(p []int, v int) int {
+	for i, x := range p {
+		if x == v {
+			return i
+		}
+	}
+	return -1
+}
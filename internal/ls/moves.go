@@ -0,0 +1,199 @@
+package ls
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// MoveKind — вид случайного хода траекторных метаэвристик (SA, TS).
+type MoveKind string
+
+const (
+	// MoveSwap — обмен работ в двух позициях.
+	MoveSwap MoveKind = "swap"
+	// MoveInsert — извлечение работы и вставка в другую позицию.
+	MoveInsert MoveKind = "insert"
+	// MoveBlock — перенос блока из 2..BlockMaxLen подряд идущих работ.
+	MoveBlock MoveKind = "block"
+	// MoveReverse — разворот подпоследовательности.
+	MoveReverse MoveKind = "reverse"
+	// MoveMixed — вид хода выбирается случайно с весами MoveWeights.
+	MoveMixed MoveKind = "mixed"
+)
+
+// MoveWeights — относительные веса видов хода в режиме mixed.
+type MoveWeights struct {
+	Swap    float64
+	Insert  float64
+	Block   float64
+	Reverse float64
+}
+
+func DefaultMoveWeights() MoveWeights {
+	return MoveWeights{Swap: 1, Insert: 1, Block: 1, Reverse: 1}
+}
+
+// ValidateMoves проверяет параметры генерации ходов.
+func ValidateMoves(kind MoveKind, blockMaxLen int, w MoveWeights) error {
+	switch kind {
+	case MoveSwap, MoveInsert, MoveReverse:
+		return nil
+	case MoveBlock:
+		// ok
+	case MoveMixed:
+		if w.Swap < 0 || w.Insert < 0 || w.Block < 0 || w.Reverse < 0 {
+			return fmt.Errorf("веса видов хода должны быть >= 0 (получено %+v)", w)
+		}
+		if w.Swap+w.Insert+w.Block+w.Reverse <= 0 {
+			return fmt.Errorf("сумма весов видов хода должна быть > 0")
+		}
+		if w.Block == 0 {
+			return nil
+		}
+	default:
+		return fmt.Errorf(
+			"неизвестный тип окрестности %q",
+			kind,
+		)
+	}
+	if blockMaxLen < 2 {
+		return fmt.Errorf(
+			"максимальная длина блока должна быть >= 2 (получено %d)",
+			blockMaxLen,
+		)
+	}
+	return nil
+}
+
+// Move — ход в перестановке.
+//
+//	swap:    обмен позиций From и To;
+//	insert:  работа из From вставляется в To;
+//	block:   блок длины Len с позиции From переносится так, чтобы начинаться с To;
+//	reverse: разворот отрезка [From, To].
+type Move struct {
+	Kind     MoveKind
+	From, To int
+	Len      int
+}
+
+// Apply применяет ход к p. Пустой ход (Kind == "") ничего не делает.
+func (m Move) Apply(p []int) {
+	switch m.Kind {
+	case MoveSwap:
+		ApplySwap(p, m.From, m.To)
+	case MoveInsert:
+		ApplyInsert(p, m.From, m.To)
+	case MoveBlock:
+		ApplyBlockMove(p, m.From, m.Len, m.To)
+	case MoveReverse:
+		Reverse(p[m.From : m.To+1])
+	}
+}
+
+// Inverse возвращает ход, отменяющий m.
+func (m Move) Inverse() Move {
+	switch m.Kind {
+	case MoveSwap, MoveInsert, MoveBlock:
+		m.From, m.To = m.To, m.From
+	}
+	return m
+}
+
+// Key — ключ хода для табу-списка: вид, длина блока, работа в позиции From
+// перестановки p и позиции хода. Ключ обратного хода вычисляется как
+// m.Inverse().Key(p) после применения m к p. Поля занимают по 16 бит.
+//
+// Равносильные ходы получают один ключ: обмен задаётся упорядоченной парой позиций
+// (swap(a, b) = swap(b, a)), вставка в соседнюю позицию — так же
+// (insert(j → j+1) = insert(j+1 → j)), поэтому обратный ход совпадает по ключу
+// с прямым независимо от порядка, в котором сгенерированы позиции.
+func (m Move) Key(p []int) uint64 {
+	var code uint64
+	switch m.Kind {
+	case MoveSwap:
+		code = 1
+	case MoveInsert:
+		code = 2
+	case MoveBlock:
+		code = 3
+	case MoveReverse:
+		code = 4
+	}
+	if m.Kind == MoveSwap || m.Kind == MoveInsert && (m.To-m.From == 1 || m.From-m.To == 1) {
+		m.From, m.To = min(m.From, m.To), max(m.From, m.To)
+	}
+	job := 0
+	if m.From < len(p) {
+		job = p[m.From]
+	}
+	return code<<60 |
+		uint64(uint16(m.Len))<<48 |
+		uint64(uint16(job))<<32 |
+		uint64(uint16(m.From))<<16 |
+		uint64(uint16(m.To))
+}
+
+// MoveSampler генерирует случайные ходы заданного вида.
+type MoveSampler struct {
+	kind        MoveKind
+	blockMaxLen int
+
+	// Накопленные веса режима mixed в порядке swap, insert, block, reverse
+	cum [4]float64
+}
+
+var mixedKinds = [4]MoveKind{MoveSwap, MoveInsert, MoveBlock, MoveReverse}
+
+// NewMoveSampler создаёт генератор ходов; параметры должны пройти ValidateMoves.
+func NewMoveSampler(kind MoveKind, blockMaxLen int, w MoveWeights) *MoveSampler {
+	s := &MoveSampler{kind: kind, blockMaxLen: blockMaxLen}
+	acc := 0.0
+	for i, v := range [4]float64{w.Swap, w.Insert, w.Block, w.Reverse} {
+		acc += v
+		s.cum[i] = acc
+	}
+	return s
+}
+
+// Sample возвращает случайный ход в перестановке длины n (пустой ход при n < 2).
+func (s *MoveSampler) Sample(n int, rng *rand.Rand) Move {
+	if n < 2 {
+		return Move{}
+	}
+	kind := s.kind
+	if kind == MoveMixed {
+		r := rng.Float64() * s.cum[len(s.cum)-1]
+		kind = mixedKinds[len(mixedKinds)-1]
+		for i, c := range s.cum {
+			if r < c {
+				kind = mixedKinds[i]
+				break
+			}
+		}
+	}
+
+	if kind == MoveBlock {
+		if maxLen := min(s.blockMaxLen, n-1); maxLen >= 2 {
+			length := 2 + rng.Intn(maxLen-1)
+			from := rng.Intn(n - length + 1)
+			to := rng.Intn(n - length)
+			if to >= from {
+				to++
+			}
+			return Move{Kind: MoveBlock, From: from, To: to, Len: length}
+		}
+		// Для n = 2 блок не помещается — обычная вставка
+		kind = MoveInsert
+	}
+
+	from := rng.Intn(n)
+	to := rng.Intn(n - 1)
+	if to >= from {
+		to++
+	}
+	if kind == MoveReverse && from > to {
+		from, to = to, from
+	}
+	return Move{Kind: kind, From: from, To: to}
+}
@@ -0,0 +1,68 @@
+package ls
+
+import "math/rand"
+
+// InitPermutation генерирует срез [0, 1, 2, ..., n-1].
+// Используется как базовое состояние перед случайной перестановкой.
+func InitPermutation(p []int) {
+	for i := range p {
+		p[i] = i
+	}
+}
+
+// ShufflePermutation выполняет случайную перестановку элементов.
+func ShufflePermutation(p []int, rng *rand.Rand) {
+	for i := len(p) - 1; i > 0; i-- {
+		j := rng.Intn(i + 1)
+		p[i], p[j] = p[j], p[i]
+	}
+}
+
+// ApplySwap применяет swap-ход (обмен элементов в позициях i и j).
+func ApplySwap(p []int, i, j int) {
+	p[i], p[j] = p[j], p[i]
+}
+
+// ApplyInsert применяет insert-ход (элемент из позиции from вставляется в позицию to).
+func ApplyInsert(p []int, from, to int) {
+	if from == to {
+		return
+	}
+	val := p[from]
+	if from < to {
+		copy(p[from:to], p[from+1:to+1])
+		p[to] = val
+		return
+	}
+	copy(p[to+1:from+1], p[to:from])
+	p[to] = val
+}
+
+// ApplyBlockMove переносит блок длины length с позиции from так,
+// чтобы он начинался с позиции to (в последовательности после переноса).
+func ApplyBlockMove(p []int, from, length, to int) {
+	if from == to {
+		return
+	}
+	if from < to {
+		// Блок сдвигается вправо: элементы [from+length, to+length) смещаются влево
+		rotateLeft(p[from:to+length], length)
+		return
+	}
+	// Блок сдвигается влево: элементы [to, from) смещаются вправо
+	rotateLeft(p[to:from+length], from-to)
+}
+
+// Reverse разворачивает срез на месте.
+func Reverse(p []int) {
+	for i, j := 0, len(p)-1; i < j; i, j = i+1, j-1 {
+		p[i], p[j] = p[j], p[i]
+	}
+}
+
+// rotateLeft циклически сдвигает срез влево на k позиций тремя разворотами.
+func rotateLeft(p []int, k int) {
+	Reverse(p[:k])
+	Reverse(p[k:])
+	Reverse(p)
+}
@@ -0,0 +1,101 @@
+package ls
+
+import "flowShop/internal/flowshop"
+
+// SegmentEvaluator оценивает перестановки, отличающиеся от опорной только на отрезке
+// позиций a..b: по головам префикса и хвостам суффикса опорной перестановки
+// пересчитывается лишь изменившийся участок, за O((b−a+1)·m). Для гибридного flow-shop
+// головы и хвосты неприменимы, и перестановка оценивается полным декодированием.
+//
+// SegmentEvaluator не безопасен для конкурентного использования.
+type SegmentEvaluator struct {
+	inst   *flowshop.Instance
+	eval   *flowshop.Evaluator
+	hybrid bool
+	m      int
+
+	// e[i] — завершение префикса из i работ, q[i] — хвост от позиции i; строки по m столбцов
+	e, q, f []int
+
+	cand []int
+}
+
+func NewSegmentEvaluator(inst *flowshop.Instance) (*SegmentEvaluator, error) {
+	eval, err := flowshop.NewEvaluator(inst)
+	if err != nil {
+		return nil, err
+	}
+	n, m := inst.Jobs, inst.Machines
+	return &SegmentEvaluator{
+		inst:   inst,
+		eval:   eval,
+		hybrid: inst.IsHybrid(),
+		m:      m,
+		e:      make([]int, (n+1)*m),
+		q:      make([]int, (n+1)*m),
+		f:      make([]int, m),
+		cand:   make([]int, 0, n),
+	}, nil
+}
+
+// Prepare вычисляет головы и хвосты опорной перестановки perm.
+func (s *SegmentEvaluator) Prepare(perm []int) {
+	if s.hybrid {
+		return
+	}
+	n, m := len(perm), s.m
+	e, q := s.e, s.q
+	inst := s.inst
+
+	for k := 0; k < m; k++ {
+		e[k] = 0
+	}
+	for i := 0; i < n; i++ {
+		row, prev := (i+1)*m, i*m
+		e[row] = e[prev] + inst.Time(perm[i], 0)
+		for k := 1; k < m; k++ {
+			e[row+k] = max(e[prev+k], e[row+k-1]) + inst.Time(perm[i], k)
+		}
+	}
+
+	for k := 0; k < m; k++ {
+		q[n*m+k] = 0
+	}
+	for i := n - 1; i >= 0; i-- {
+		row, next := i*m, (i+1)*m
+		q[row+m-1] = q[next+m-1] + inst.Time(perm[i], m-1)
+		for k := m - 2; k >= 0; k-- {
+			q[row+k] = max(q[next+k], q[row+k+1]) + inst.Time(perm[i], k)
+		}
+	}
+}
+
+// Makespan оценивает perm, в которой позиции a..b заменены на seg.
+// Головы и хвосты должны быть вычислены для perm вызовом Prepare.
+func (s *SegmentEvaluator) Makespan(perm []int, a, b int, seg []int) int {
+	if s.hybrid {
+		s.cand = append(s.cand[:0], perm[:a]...)
+		s.cand = append(s.cand, seg...)
+		s.cand = append(s.cand, perm[b+1:]...)
+		return s.eval.PartialMakespan(s.cand)
+	}
+
+	m := s.m
+	f := s.f
+	copy(f, s.e[a*m:(a+1)*m])
+	for _, job := range seg {
+		f[0] += s.inst.Time(job, 0)
+		for k := 1; k < m; k++ {
+			f[k] = max(f[k], f[k-1]) + s.inst.Time(job, k)
+		}
+	}
+
+	tail := s.q[(b+1)*m:]
+	ms := 0
+	for k := 0; k < m; k++ {
+		if v := f[k] + tail[k]; v > ms {
+			ms = v
+		}
+	}
+	return ms
+}
@@ -0,0 +1,53 @@
+package nonperm
+
+import "fmt"
+
+type Config struct {
+	Iterations       int
+	IterationsPerJob int
+
+	TabuTenure     int
+	TabuTenureRand int
+
+	// NeighborsPerIter — число случайных обменов соседних работ на одном станке за итерацию.
+	NeighborsPerIter int
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Iterations:       0,
+		IterationsPerJob: 20,
+
+		TabuTenure:     7,
+		TabuTenureRand: 3,
+
+		NeighborsPerIter: 60,
+	}
+}
+
+func (c Config) Validate() error {
+	if c.Iterations <= 0 && c.IterationsPerJob <= 0 {
+		return fmt.Errorf(
+			"должно быть задано Iterations > 0 или IterationsPerJob > 0",
+		)
+	}
+	if c.TabuTenure <= 0 {
+		return fmt.Errorf(
+			"TabuTenure должно быть > 0 (получено %d)",
+			c.TabuTenure,
+		)
+	}
+	if c.TabuTenureRand < 0 {
+		return fmt.Errorf(
+			"TabuTenureRand должно быть >= 0 (получено %d)",
+			c.TabuTenureRand,
+		)
+	}
+	if c.NeighborsPerIter <= 0 {
+		return fmt.Errorf(
+			"NeighborsPerIter должно быть > 0 (получено %d)",
+			c.NeighborsPerIter,
+		)
+	}
+	return nil
+}
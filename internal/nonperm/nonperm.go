@@ -0,0 +1,235 @@
+package nonperm
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"flowShop/internal/flowshop"
+	"flowShop/internal/opt"
+)
+
+// maxInt используется как бесконечность для стоимостей.
+const maxInt = int(^uint(0) >> 1)
+
+// Solver — табу-поиск для неперестановочного flow-shop.
+// Порядок работ на каждом станке задаётся отдельно; поиск стартует
+// с лучшего перестановочного решения, найденного алгоритмом Base.
+type Solver struct {
+	Cfg  Config
+	Base opt.Optimizer
+	Rng  *rand.Rand
+}
+
+// Result — результат неперестановочного поиска.
+// Встроенный opt.Result согласован: содержит стартовую перестановку и её makespan.
+type Result struct {
+	opt.Result
+
+	// Sequences — порядок работ на каждом станке
+	Sequences [][]int
+	// SequencesMakespan — makespan лучшего неперестановочного расписания Sequences
+	SequencesMakespan int
+	// PermutationMakespan — makespan стартового перестановочного решения
+	PermutationMakespan int
+	// Gain — выигрыш относительно перестановочного решения (>= 0)
+	Gain int
+	// GainPercent — выигрыш в процентах от PermutationMakespan
+	GainPercent float64
+}
+
+// New возвращает новый солвер с валидацией конфигурации.
+// base — перестановочный алгоритм, дающий начальное решение.
+func New(cfg Config, base opt.Optimizer, rng *rand.Rand) (*Solver, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if base == nil {
+		return nil, fmt.Errorf("базовый перестановочный алгоритм не задан (nil)")
+	}
+	if rng == nil {
+		return nil, fmt.Errorf("генератор случайных чисел не инициализирован (nil)")
+	}
+	return &Solver{Cfg: cfg, Base: base, Rng: rng}, nil
+}
+
+// Solve реализует opt.Optimizer: возвращает стартовую перестановку и её makespan.
+// Makespan неперестановочного расписания, последовательности по станкам и выигрыш
+// доступны в Meta ("sequences_makespan", "sequences", "gain") и через SolveSequences.
+func (s *Solver) Solve(ctx context.Context, inst *flowshop.Instance) (opt.Result, error) {
+	res, err := s.SolveSequences(ctx, inst)
+	return res.Result, err
+}
+
+// SolveSequences — основной цикл алгоритма.
+func (s *Solver) SolveSequences(ctx context.Context, inst *flowshop.Instance) (Result, error) {
+	start := time.Now()
+
+	if err := inst.Validate(); err != nil {
+		return Result{}, err
+	}
+	if inst.IsHybrid() {
+		return Result{}, fmt.Errorf("неперестановочный поиск не поддерживает гибридный flow-shop: %w", opt.ErrUnsupported)
+	}
+	if err := s.Cfg.Validate(); err != nil {
+		return Result{}, err
+	}
+	if s.Base == nil {
+		return Result{}, fmt.Errorf("базовый перестановочный алгоритм не задан (nil)")
+	}
+	if s.Rng == nil {
+		return Result{}, fmt.Errorf("генератор случайных чисел не инициализирован (nil)")
+	}
+
+	// Стартовое решение — лучшая перестановка базового алгоритма
+	baseRes, err := s.Base.Solve(ctx, inst)
+	if err != nil {
+		return Result{}, fmt.Errorf("базовый алгоритм: %w", err)
+	}
+
+	eval, err := flowshop.NewEvaluator(inst)
+	if err != nil {
+		return Result{}, err
+	}
+
+	n, m := inst.Jobs, inst.Machines
+
+	maxIter := s.Cfg.Iterations
+	if maxIter <= 0 {
+		maxIter = s.Cfg.IterationsPerJob * n
+	}
+
+	permCost, err := eval.Makespan(baseRes.Permutation)
+	if err != nil {
+		return Result{}, fmt.Errorf("базовый алгоритм: %w", err)
+	}
+	evals := baseRes.Evaluations + 1
+
+	curr := flowshop.PermutationSequences(baseRes.Permutation, m)
+	best := flowshop.PermutationSequences(baseRes.Permutation, m)
+	currCost := permCost
+	bestCost := permCost
+
+	tabu := make(map[uint64]int, 256)
+
+	finish := func(iters int, meta map[string]any) Result {
+		gain := permCost - bestCost
+		gainPct := 0.0
+		if permCost > 0 {
+			gainPct = 100 * float64(gain) / float64(permCost)
+		}
+		meta["permutation_makespan"] = permCost
+		meta["sequences_makespan"] = bestCost
+		meta["gain"] = gain
+		meta["gain_pct"] = gainPct
+		meta["sequences"] = best
+
+		permCopy := make([]int, n)
+		copy(permCopy, baseRes.Permutation)
+		return Result{
+			Result: opt.Result{
+				Permutation: permCopy,
+				Makespan:    permCost,
+				Evaluations: evals,
+				Iterations:  iters,
+				Duration:    time.Since(start),
+				Meta:        meta,
+			},
+			Sequences:           best,
+			SequencesMakespan:   bestCost,
+			PermutationMakespan: permCost,
+			Gain:                gain,
+			GainPercent:         gainPct,
+		}
+	}
+
+	if n < 2 {
+		return finish(0, map[string]any{}), nil
+	}
+
+	for iter := 0; iter < maxIter; iter++ {
+		// Для поддержки отмены через context
+		if err := ctx.Err(); err != nil {
+			return finish(iter, map[string]any{"stopped": "context"}), err
+		}
+
+		// Лучший допустимый ход и запасной (лучший без учёта табу)
+		bestMoveMachine, bestMovePos := -1, -1
+		bestMoveCost := maxInt
+		fallbackMachine, fallbackPos := -1, -1
+		fallbackCost := maxInt
+
+		for k := 0; k < s.Cfg.NeighborsPerIter; k++ {
+			machine := s.Rng.Intn(m)
+			pos := s.Rng.Intn(n - 1)
+			seq := curr[machine]
+
+			// Обмен соседних работ на одном станке
+			seq[pos], seq[pos+1] = seq[pos+1], seq[pos]
+			cost := eval.MustSequencesMakespan(curr)
+			seq[pos], seq[pos+1] = seq[pos+1], seq[pos]
+			evals++
+
+			if cost < fallbackCost {
+				fallbackCost = cost
+				fallbackMachine, fallbackPos = machine, pos
+			}
+
+			// Ход табуирован, если он восстанавливает недавно разрушенный порядок пары работ
+			key := pairKey(machine, seq[pos+1], seq[pos])
+			if exp, ok := tabu[key]; ok && exp > iter && cost >= bestCost {
+				continue
+			}
+			if cost < bestMoveCost {
+				bestMoveCost = cost
+				bestMoveMachine, bestMovePos = machine, pos
+			}
+		}
+
+		if bestMoveMachine < 0 {
+			bestMoveMachine, bestMovePos = fallbackMachine, fallbackPos
+			bestMoveCost = fallbackCost
+		}
+
+		// Применение выбранного хода; прежний порядок пары становится табу
+		seq := curr[bestMoveMachine]
+		seq[bestMovePos], seq[bestMovePos+1] = seq[bestMovePos+1], seq[bestMovePos]
+		currCost = bestMoveCost
+
+		tenure := s.Cfg.TabuTenure
+		if s.Cfg.TabuTenureRand > 0 {
+			tenure += s.Rng.Intn(s.Cfg.TabuTenureRand + 1)
+		}
+		tabu[pairKey(bestMoveMachine, seq[bestMovePos+1], seq[bestMovePos])] = iter + tenure
+
+		// Периодическая очистка истёкших записей
+		if len(tabu) > 4*(s.Cfg.TabuTenure+s.Cfg.TabuTenureRand)+64 {
+			for key, exp := range tabu {
+				if exp <= iter {
+					delete(tabu, key)
+				}
+			}
+		}
+
+		if currCost < bestCost {
+			bestCost = currCost
+			for k := range best {
+				copy(best[k], curr[k])
+			}
+		}
+	}
+
+	return finish(maxIter, map[string]any{
+		"tabu_tenure":        s.Cfg.TabuTenure,
+		"tabu_tenure_rand":   s.Cfg.TabuTenureRand,
+		"neighbors_per_iter": s.Cfg.NeighborsPerIter,
+	}), nil
+}
+
+// pairKey формирует ключ табу для упорядоченной пары работ (first перед second) на станке.
+func pairKey(machine, first, second int) uint64 {
+	return (uint64(uint32(machine)) << 42) |
+		(uint64(uint32(first)) << 21) |
+		uint64(uint32(second))
+}
@@ -2,6 +2,7 @@ package opt
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"flowShop/internal/flowshop"
@@ -19,3 +20,7 @@ type Result struct {
 	Duration    time.Duration
 	Meta        map[string]any
 }
+
+// ErrUnsupported возвращается алгоритмом, неприменимым к данному экземпляру
+// (например, точный метод для двух станков при m != 2). Бенчмарк пропускает такие конфигурации.
+var ErrUnsupported = errors.New("алгоритм неприменим к экземпляру")
@@ -0,0 +1,83 @@
+package opt
+
+import (
+	"context"
+	"time"
+
+	"flowShop/internal/flowshop"
+)
+
+// MultiOptimizer — многокритериальный алгоритм, возвращающий множество Парето.
+type MultiOptimizer interface {
+	SolvePareto(ctx context.Context, inst *flowshop.Instance) (ParetoResult, error)
+}
+
+// ParetoSolution — недоминируемое решение с вектором значений критериев.
+type ParetoSolution struct {
+	Permutation []int
+	Objectives  []int
+}
+
+type ParetoResult struct {
+	Objectives  []flowshop.Objective
+	Front       []ParetoSolution
+	Evaluations int
+	Iterations  int
+	Duration    time.Duration
+	Meta        map[string]any
+}
+
+// Dominates сообщает, доминирует ли вектор a вектор b (минимизация всех критериев).
+func Dominates(a, b []int) bool {
+	strictly := false
+	for i := range a {
+		if a[i] > b[i] {
+			return false
+		}
+		if a[i] < b[i] {
+			strictly = true
+		}
+	}
+	return strictly
+}
+
+// Archive — архив недоминируемых решений без повторов векторов критериев.
+type Archive struct {
+	Solutions []ParetoSolution
+}
+
+// Add пытается добавить решение в архив; возвращает true, если оно принято.
+// Перестановка и вектор критериев копируются.
+func (a *Archive) Add(perm []int, objs []int) bool {
+	for _, s := range a.Solutions {
+		if Dominates(s.Objectives, objs) || equalInts(s.Objectives, objs) {
+			return false
+		}
+	}
+	kept := a.Solutions[:0]
+	for _, s := range a.Solutions {
+		if !Dominates(objs, s.Objectives) {
+			kept = append(kept, s)
+		}
+	}
+	for i := len(kept); i < len(a.Solutions); i++ {
+		a.Solutions[i] = ParetoSolution{}
+	}
+	a.Solutions = append(kept, ParetoSolution{
+		Permutation: append([]int(nil), perm...),
+		Objectives:  append([]int(nil), objs...),
+	})
+	return true
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -1,6 +1,10 @@
 package pso
 
-import "fmt"
+import (
+	"fmt"
+
+	"flowShop/internal/ls"
+)
 
 type Config struct {
 	Iterations       int
@@ -16,6 +20,11 @@ type Config struct {
 
 	PosMin float64
 	PosMax float64
+
+	// LocalSearch — локальный спуск, применяемый к лучшей частице итерации;
+	// улучшенная перестановка записывается обратно в ключи частицы
+	// перекодированием рангов. Нулевое значение отключает гибридизацию
+	LocalSearch ls.Config
 }
 
 func DefaultConfig() Config {
@@ -69,5 +78,10 @@ func (c Config) Validate() error {
 			)
 		}
 	}
+	if c.LocalSearch.Enabled() {
+		if err := c.LocalSearch.Validate(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
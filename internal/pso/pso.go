@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"flowShop/internal/flowshop"
+	"flowShop/internal/ls"
 	"flowShop/internal/opt"
 )
 
@@ -68,6 +69,16 @@ func (s *Solver) Solve(ctx context.Context, inst *flowshop.Instance) (opt.Result
 		return opt.Result{}, err
 	}
 
+	// Локальный спуск для гибридизации (nil — отключён)
+	var searcher *ls.Searcher
+	if s.Cfg.LocalSearch.Enabled() {
+		searcher, err = ls.New(s.Cfg.LocalSearch, inst)
+		if err != nil {
+			return opt.Result{}, err
+		}
+	}
+	lsEvals := 0
+
 	n := inst.Jobs
 
 	iters := s.Cfg.Iterations
@@ -132,6 +143,7 @@ func (s *Solver) Solve(ctx context.Context, inst *flowshop.Instance) (opt.Result
 	}
 
 	w, c1, c2 := s.Cfg.W, s.Cfg.C1, s.Cfg.C2
+	keyScratch := make([]float64, n)
 	vMax := s.Cfg.VMax
 
 	// Основной цикл
@@ -150,6 +162,8 @@ func (s *Solver) Solve(ctx context.Context, inst *flowshop.Instance) (opt.Result
 			}, err
 		}
 
+		iterBest, iterBestCost := -1, math.MaxInt
+
 		for i := range ps {
 			p := &ps[i]
 
@@ -190,6 +204,9 @@ func (s *Solver) Solve(ctx context.Context, inst *flowshop.Instance) (opt.Result
 			decodeRandomKeys(p.pos, p.permScratch, p.idxScratch)
 			cost := eval.MustMakespan(p.permScratch)
 			evals++
+			if cost < iterBestCost {
+				iterBest, iterBestCost = i, cost
+			}
 
 			// Обновление личного лучшего решения
 			if cost < p.pBestCost {
@@ -204,23 +221,49 @@ func (s *Solver) Solve(ctx context.Context, inst *flowshop.Instance) (opt.Result
 				copy(gBestPerm, p.permScratch)
 			}
 		}
+
+		// Локальный спуск от лучшей частицы итерации (ламарковская запись в ключи)
+		if searcher != nil && iterBest >= 0 {
+			p := &ps[iterBest]
+			cost, k := searcher.Improve(ctx, p.permScratch, iterBestCost)
+			evals += k
+			lsEvals += k
+			if cost < iterBestCost {
+				encodeRandomKeys(p.permScratch, p.pos, keyScratch)
+				if cost < p.pBestCost {
+					p.pBestCost = cost
+					copy(p.pBestPos, p.pos)
+				}
+				if cost < gBestCost {
+					gBestCost = cost
+					copy(gBestPos, p.pos)
+					copy(gBestPerm, p.permScratch)
+				}
+			}
+		}
 	}
 
+	meta := map[string]any{
+		"particles": s.Cfg.Particles,
+		"w":         w,
+		"c1":        c1,
+		"c2":        c2,
+		"vmax":      vMax,
+		"pos_min":   posMin,
+		"pos_max":   posMax,
+	}
+	if searcher != nil {
+		meta["local_search"] = string(s.Cfg.LocalSearch.Neighborhood)
+		meta["ls_strategy"] = string(s.Cfg.LocalSearch.Strategy)
+		meta["ls_evaluations"] = lsEvals
+	}
 	return opt.Result{
 		Permutation: gBestPerm,
 		Makespan:    gBestCost,
 		Evaluations: evals,
 		Iterations:  iters,
 		Duration:    time.Since(start),
-		Meta: map[string]any{
-			"particles": s.Cfg.Particles,
-			"w":         w,
-			"c1":        c1,
-			"c2":        c2,
-			"vmax":      vMax,
-			"pos_min":   posMin,
-			"pos_max":   posMax,
-		},
+		Meta:        meta,
 	}, nil
 }
 
@@ -244,3 +287,20 @@ func decodeRandomKeys(keys []float64, outPerm []int, idxScratch []int) {
 		outPerm[i] = idxScratch[i]
 	}
 }
+
+// encodeRandomKeys перекодирует ключи так, чтобы они декодировались в perm:
+// отсортированные значения ключей раздаются работам в порядке perm.
+// Множество значений ключей сохраняется; совпадающие значения (например, после
+// ограничения позиций) раздвигаются на минимальный шаг, чтобы порядок был однозначным.
+func encodeRandomKeys(perm []int, keys []float64, sortedScratch []float64) {
+	copy(sortedScratch, keys)
+	sort.Float64s(sortedScratch)
+	for r := 1; r < len(sortedScratch); r++ {
+		if sortedScratch[r] <= sortedScratch[r-1] {
+			sortedScratch[r] = math.Nextafter(sortedScratch[r-1], math.Inf(1))
+		}
+	}
+	for r, job := range perm {
+		keys[job] = sortedScratch[r]
+	}
+}
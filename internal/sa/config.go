@@ -1,24 +1,63 @@
 package sa
 
-import "fmt"
+import (
+	"fmt"
 
-// Тип окрестности
+	"flowShop/internal/ls"
+)
+
+// Тип окрестности (см. ls.MoveKind)
 type Neighborhood string
 
 const (
-	NeighborhoodSwap   Neighborhood = "swap"
-	NeighborhoodInsert Neighborhood = "insert"
+	NeighborhoodSwap    Neighborhood = "swap"
+	NeighborhoodInsert  Neighborhood = "insert"
+	NeighborhoodBlock   Neighborhood = "block"
+	NeighborhoodReverse Neighborhood = "reverse"
+	NeighborhoodMixed   Neighborhood = "mixed"
 )
 
 type Config struct {
 	Iterations       int
 	IterationsPerJob int
 
+	// TempMode — способ задания температур; InitialTemp и FinalTemp используются
+	// только режимом fixed
+	TempMode    TempMode
 	InitialTemp float64
 	FinalTemp   float64
 	Alpha       float64
 
+	// InitialAcceptance, FinalAcceptance — целевые вероятности принятия ухудшающего хода
+	// в начале и в конце отжига; CalibrationSamples — число ухудшающих ходов в выборке
+	// (режим acceptance)
+	InitialAcceptance  float64
+	FinalAcceptance    float64
+	CalibrationSamples int
+
+	// InitialTempFactor, FinalTempFactor — коэффициенты c формулы Османа–Поттса
+	InitialTempFactor float64
+	FinalTempFactor   float64
+
+	// Cooling — схема охлаждения (Alpha используется только схемой geometric)
+	Cooling Cooling
+	// ChainLength — длина цепи Маркова: число итераций на одном уровне температуры
+	ChainLength int
+
+	// StallIterations — число итераций без улучшения лучшего решения,
+	// после которого выполняется повторный нагрев (0 — без нагрева)
+	StallIterations int
+	// ReheatRatio — температура нагрева в долях начальной температуры
+	// (InitialTemp или откалиброванной); должна давать температуру выше конечной
+	ReheatRatio float64
+	// RestartFromBest — при нагреве продолжать поиск из лучшего найденного решения
+	RestartFromBest bool
+
 	Neighborhood Neighborhood
+	// BlockMaxLen — максимальная длина переносимого блока (окрестности block и mixed)
+	BlockMaxLen int
+	// MoveWeights — веса видов хода окрестности mixed
+	MoveWeights ls.MoveWeights
 }
 
 func DefaultConfig() Config {
@@ -26,11 +65,28 @@ func DefaultConfig() Config {
 		Iterations:       0,
 		IterationsPerJob: 2500,
 
+		TempMode:    TempFixed,
 		InitialTemp: 2000.0,
 		FinalTemp:   0.5,
 		Alpha:       0.995,
 
+		InitialAcceptance:  0.8,
+		FinalAcceptance:    0.01,
+		CalibrationSamples: 200,
+
+		InitialTempFactor: 5.0,
+		FinalTempFactor:   0.1,
+
+		Cooling:     CoolingGeometric,
+		ChainLength: 1,
+
+		StallIterations: 0,
+		ReheatRatio:     0.5,
+		RestartFromBest: true,
+
 		Neighborhood: NeighborhoodSwap,
+		BlockMaxLen:  3,
+		MoveWeights:  ls.DefaultMoveWeights(),
 	}
 }
 
@@ -40,39 +96,134 @@ func (c Config) Validate() error {
 			"должно быть задано Iterations > 0 или IterationsPerJob > 0",
 		)
 	}
-	if c.InitialTemp <= 0 {
+	switch c.TempMode {
+	case TempFixed:
+		if c.InitialTemp <= 0 {
+			return fmt.Errorf(
+				"InitialTemp должно быть > 0 (получено %f)",
+				c.InitialTemp,
+			)
+		}
+		if c.FinalTemp <= 0 {
+			return fmt.Errorf(
+				"FinalTemp должно быть > 0 (получено %f)",
+				c.FinalTemp,
+			)
+		}
+		if c.FinalTemp >= c.InitialTemp {
+			return fmt.Errorf(
+				"FinalTemp должно быть < InitialTemp (получено %f >= %f)",
+				c.FinalTemp,
+				c.InitialTemp,
+			)
+		}
+		if c.StallIterations > 0 && c.ReheatRatio*c.InitialTemp <= c.FinalTemp {
+			return fmt.Errorf(
+				"температура нагрева ReheatRatio·InitialTemp должна быть > FinalTemp (получено %f <= %f)",
+				c.ReheatRatio*c.InitialTemp,
+				c.FinalTemp,
+			)
+		}
+	case TempAcceptance:
+		if c.FinalAcceptance <= 0 || c.FinalAcceptance >= c.InitialAcceptance || c.InitialAcceptance >= 1 {
+			return fmt.Errorf(
+				"вероятности принятия должны удовлетворять 0 < FinalAcceptance < InitialAcceptance < 1 (получено %f, %f)",
+				c.FinalAcceptance,
+				c.InitialAcceptance,
+			)
+		}
+		if c.CalibrationSamples <= 0 {
+			return fmt.Errorf(
+				"CalibrationSamples должно быть > 0 (получено %d)",
+				c.CalibrationSamples,
+			)
+		}
+	case TempOsmanPotts:
+		if c.FinalTempFactor <= 0 || c.FinalTempFactor >= c.InitialTempFactor {
+			return fmt.Errorf(
+				"коэффициенты должны удовлетворять 0 < FinalTempFactor < InitialTempFactor (получено %f, %f)",
+				c.FinalTempFactor,
+				c.InitialTempFactor,
+			)
+		}
+	default:
 		return fmt.Errorf(
-			"InitialTemp должно быть > 0 (получено %f)",
-			c.InitialTemp,
+			"неизвестный режим задания температуры %q",
+			c.TempMode,
 		)
 	}
-	if c.FinalTemp <= 0 {
+	switch c.Cooling {
+	case CoolingGeometric:
+		if c.Alpha <= 0 || c.Alpha >= 1 {
+			return fmt.Errorf(
+				"alpha должно лежать в интервале (0,1) (получено %f)",
+				c.Alpha,
+			)
+		}
+	case CoolingLinear, CoolingLogarithmic, CoolingLundyMees, CoolingAuto:
+		// ok
+	default:
 		return fmt.Errorf(
-			"FinalTemp должно быть > 0 (получено %f)",
-			c.FinalTemp,
+			"неизвестная схема охлаждения %q",
+			c.Cooling,
 		)
 	}
-	if c.FinalTemp >= c.InitialTemp {
+	if c.ChainLength <= 0 {
 		return fmt.Errorf(
-			"FinalTemp должно быть < InitialTemp (получено %f >= %f)",
-			c.FinalTemp,
-			c.InitialTemp,
+			"ChainLength должно быть > 0 (получено %d)",
+			c.ChainLength,
 		)
 	}
-	if c.Alpha <= 0 || c.Alpha >= 1 {
+	if c.StallIterations < 0 {
 		return fmt.Errorf(
-			"alpha должно лежать в интервале (0,1) (получено %f)",
-			c.Alpha,
+			"StallIterations должно быть >= 0 (получено %d)",
+			c.StallIterations,
 		)
 	}
-	switch c.Neighborhood {
-	case NeighborhoodSwap, NeighborhoodInsert:
-		// ok
-	default:
+	if c.StallIterations > 0 && (c.ReheatRatio <= 0 || c.ReheatRatio > 1) {
+		return fmt.Errorf(
+			"ReheatRatio должно лежать в интервале (0,1] (получено %f)",
+			c.ReheatRatio,
+		)
+	}
+	return ls.ValidateMoves(ls.MoveKind(c.Neighborhood), c.BlockMaxLen, c.MoveWeights)
+}
+
+// TemperingConfig — параметры параллельного отжига (обмена репликами).
+type TemperingConfig struct {
+	// Replicas — число реплик; температуры образуют геометрическую лестницу
+	// от FinalTemp (самая холодная) до InitialTemp (самая горячая) конфигурации SA
+	Replicas int
+	// SA — параметры реплики: окрестность, бюджет итераций (на каждую реплику)
+	// и способ задания температур; схема охлаждения и нагрев не используются
+	SA Config
+	// SwapInterval — число итераций между попытками обмена соседних реплик
+	SwapInterval int
+}
+
+func DefaultTemperingConfig() TemperingConfig {
+	cfg := DefaultConfig()
+	cfg.TempMode = TempAcceptance
+	cfg.IterationsPerJob = 1000
+	return TemperingConfig{
+		Replicas:     8,
+		SA:           cfg,
+		SwapInterval: 50,
+	}
+}
+
+func (c TemperingConfig) Validate() error {
+	if c.Replicas < 2 {
+		return fmt.Errorf(
+			"число реплик должно быть >= 2 (получено %d)",
+			c.Replicas,
+		)
+	}
+	if c.SwapInterval <= 0 {
 		return fmt.Errorf(
-			"неизвестный тип окрестности %q",
-			c.Neighborhood,
+			"интервал обмена репликами должен быть > 0 (получено %d)",
+			c.SwapInterval,
 		)
 	}
-	return nil
+	return c.SA.Validate()
 }
@@ -0,0 +1,71 @@
+package sa
+
+import "math"
+
+// Cooling — схема охлаждения температуры.
+type Cooling string
+
+const (
+	// CoolingGeometric — T ← α·T на каждом уровне температуры.
+	CoolingGeometric Cooling = "geometric"
+	// CoolingLinear — T ← T − (T0 − Tf)/L, где L — число уровней температуры.
+	CoolingLinear Cooling = "linear"
+	// CoolingLogarithmic — T_k = T0·ln 2 / ln(k + 2) (медленное охлаждение Гемана).
+	CoolingLogarithmic Cooling = "logarithmic"
+	// CoolingLundyMees — T ← T/(1 + β·T), β = (T0 − Tf)/(L·T0·Tf).
+	CoolingLundyMees Cooling = "lundy-mees"
+	// CoolingAuto — геометрическая схема с α = (Tf/T0)^(1/L):
+	// температура достигает FinalTemp ровно к концу бюджета итераций.
+	CoolingAuto Cooling = "auto"
+)
+
+// schedule вычисляет температуру очередного уровня.
+// Уровни отсчитываются от последнего нагрева (start — температура в его момент).
+type schedule struct {
+	cooling Cooling
+	alpha   float64 // geometric, auto
+	step    float64 // linear
+	beta    float64 // lundy-mees
+}
+
+// newSchedule подбирает параметры схемы так, чтобы за levels уровней
+// температура снизилась с t0 до tf (кроме geometric и logarithmic).
+func newSchedule(cfg Config, t0, tf float64, levels int) schedule {
+	l := float64(max(levels, 1))
+	sc := schedule{cooling: cfg.Cooling, alpha: cfg.Alpha}
+	switch cfg.Cooling {
+	case CoolingAuto:
+		sc.alpha = math.Pow(tf/t0, 1/l)
+	case CoolingLinear:
+		sc.step = (t0 - tf) / l
+	case CoolingLundyMees:
+		sc.beta = (t0 - tf) / (l * t0 * tf)
+	}
+	return sc
+}
+
+// next возвращает температуру уровня k ≥ 1 по температуре t предыдущего уровня.
+func (sc schedule) next(t, start float64, k int) float64 {
+	switch sc.cooling {
+	case CoolingLinear:
+		return t - sc.step
+	case CoolingLogarithmic:
+		return start * math.Ln2 / math.Log(float64(k+2))
+	case CoolingLundyMees:
+		return t / (1 + sc.beta*t)
+	}
+	return t * sc.alpha
+}
+
+// meta дополняет Meta параметрами схемы охлаждения.
+func (sc schedule) meta(meta map[string]any) {
+	meta["cooling"] = string(sc.cooling)
+	switch sc.cooling {
+	case CoolingGeometric, CoolingAuto:
+		meta["alpha"] = sc.alpha
+	case CoolingLinear:
+		meta["cooling_step"] = sc.step
+	case CoolingLundyMees:
+		meta["beta"] = sc.beta
+	}
+}
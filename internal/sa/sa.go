@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"flowShop/internal/flowshop"
+	"flowShop/internal/ls"
 	"flowShop/internal/opt"
 )
 
@@ -51,28 +52,58 @@ func (s *Solver) Solve(ctx context.Context, inst *flowshop.Instance) (opt.Result
 
 	n := inst.Jobs
 
+	// Начальная и конечная температуры
+	temps := s.calibrate(inst, eval)
+	if temps.tf >= temps.t0 {
+		return opt.Result{}, fmt.Errorf(
+			"конечная температура должна быть < начальной (получено %f >= %f)",
+			temps.tf,
+			temps.t0,
+		)
+	}
+	// Для откалиброванных температур соотношение с ReheatRatio проверяется только здесь
+	if s.Cfg.StallIterations > 0 && s.Cfg.ReheatRatio*temps.t0 <= temps.tf {
+		return opt.Result{}, fmt.Errorf(
+			"температура нагрева ReheatRatio·T0 должна быть > конечной (получено %f <= %f)",
+			s.Cfg.ReheatRatio*temps.t0,
+			temps.tf,
+		)
+	}
+
 	maxIter := s.Cfg.Iterations
 	if maxIter <= 0 {
 		maxIter = s.Cfg.IterationsPerJob * n
 	}
 
+	// Генератор соседних решений
+	moves := s.moves()
+
 	// Текущее и кандидатное решения
 	curr := make([]int, n)
 	cand := make([]int, n)
 
 	// Инициализация текущего решения
-	initPermutation(curr)
-	shufflePermutation(curr, s.Rng)
+	ls.InitPermutation(curr)
+	ls.ShufflePermutation(curr, s.Rng)
 
 	currCost := eval.MustMakespan(curr)
 	bestCost := currCost
 	best := make([]int, n)
 	copy(best, curr)
 
-	evals := 1
-	T := s.Cfg.InitialTemp
+	evals := 1 + temps.evals
+	T := temps.t0
 
-	for iter := 0; iter < maxIter && T > s.Cfg.FinalTemp; iter++ {
+	// Схема охлаждения: уровень температуры сменяется каждые ChainLength итераций
+	chain := s.Cfg.ChainLength
+	sched := newSchedule(s.Cfg, temps.t0, temps.tf, (maxIter+chain-1)/chain)
+	level, levelStart, inChain := 0, T, 0
+
+	// Повторный нагрев при застое
+	lastImprove, reheats := 0, 0
+
+	iter := 0
+	for ; iter < maxIter && T > temps.tf; iter++ {
 		// Для поддержки отмены через context
 		if err := ctx.Err(); err != nil {
 			return opt.Result{
@@ -84,40 +115,18 @@ func (s *Solver) Solve(ctx context.Context, inst *flowshop.Instance) (opt.Result
 				Meta: map[string]any{
 					"stopped": "context",
 					"T":       T,
+					"reheats": reheats,
 				},
 			}, err
 		}
 
 		copy(cand, curr)
-		switch s.Cfg.Neighborhood {
-		case NeighborhoodSwap:
-			// Окрестность на основе обмена двух элементов
-			neighborSwap(cand, s.Rng)
-		case NeighborhoodInsert:
-			// Окрестность на основе вставки элемента в другую позицию
-			neighborInsert(cand, s.Rng)
-		default:
-			neighborSwap(cand, s.Rng)
-		}
+		moves.Sample(n, s.Rng).Apply(cand)
 
 		candCost := eval.MustMakespan(cand)
 		evals++
 
-		delta := candCost - currCost
-		accept := false
-		if delta <= 0 {
-			// Улучшающее решение принимаем всегда
-			accept = true
-		} else {
-			// Критерий Метрополиса:
-			// допускает принятие ухудшающих решений
-			p := math.Exp(-float64(delta) / T)
-			if s.Rng.Float64() < p {
-				accept = true
-			}
-		}
-
-		if accept {
+		if metropolis(candCost-currCost, T, s.Rng) {
 			// Обмен ролей текущего и кандидатного решений
 			curr, cand = cand, curr
 			currCost = candCost
@@ -126,78 +135,73 @@ func (s *Solver) Solve(ctx context.Context, inst *flowshop.Instance) (opt.Result
 			if currCost < bestCost {
 				bestCost = currCost
 				copy(best, curr)
+				lastImprove = iter
 			}
 		}
 
-		// Охлаждение температуры
-		T *= s.Cfg.Alpha
+		// Повторный нагрев: новая серия охлаждения от ReheatRatio·T0
+		if s.Cfg.StallIterations > 0 && iter-lastImprove >= s.Cfg.StallIterations {
+			T = s.Cfg.ReheatRatio * temps.t0
+			level, levelStart, inChain = 0, T, 0
+			lastImprove = iter
+			reheats++
+			if s.Cfg.RestartFromBest {
+				copy(curr, best)
+				currCost = bestCost
+			}
+			continue
+		}
+
+		// Охлаждение температуры в конце цепи Маркова
+		inChain++
+		if inChain == chain {
+			inChain = 0
+			level++
+			T = sched.next(T, levelStart, level)
+		}
+	}
+
+	meta := map[string]any{
+		"temp_mode":    string(s.Cfg.TempMode),
+		"initial_temp": temps.t0,
+		"final_temp":   temps.tf,
+		"chain_length": chain,
+		"reheats":      reheats,
+		"neighborhood": string(s.Cfg.Neighborhood),
+	}
+	sched.meta(meta)
+	switch s.Cfg.TempMode {
+	case TempAcceptance:
+		meta["initial_acceptance"] = s.Cfg.InitialAcceptance
+		meta["final_acceptance"] = s.Cfg.FinalAcceptance
+		meta["calibration_evaluations"] = temps.evals
+	case TempOsmanPotts:
+		meta["initial_temp_factor"] = s.Cfg.InitialTempFactor
+		meta["final_temp_factor"] = s.Cfg.FinalTempFactor
 	}
 
 	return opt.Result{
 		Permutation: best,
 		Makespan:    bestCost,
 		Evaluations: evals,
-		Iterations:  maxIter,
+		Iterations:  iter,
 		Duration:    time.Since(start),
-		Meta: map[string]any{
-			"initial_temp": s.Cfg.InitialTemp,
-			"final_temp":   s.Cfg.FinalTemp,
-			"alpha":        s.Cfg.Alpha,
-			"neighborhood": string(s.Cfg.Neighborhood),
-		},
+		Meta:        meta,
 	}, nil
 }
 
-// initPermutation генерирует срез [0, 1, 2, ..., n-1].
-// Используется как базовое состояние перед случайной перестановкой.
-func initPermutation(p []int) {
-	for i := range p {
-		p[i] = i
-	}
-}
-
-// shufflePermutation выполняет случайную перестановку элементов.
-func shufflePermutation(p []int, rng *rand.Rand) {
-	for i := len(p) - 1; i > 0; i-- {
-		j := rng.Intn(i + 1)
-		p[i], p[j] = p[j], p[i]
-	}
-}
-
-// Формирует соседнее решение путём обмена двух случайных позиций.
-func neighborSwap(p []int, rng *rand.Rand) {
-	if len(p) < 2 {
-		return
+// metropolis решает, принять ли ход с приращением delta при температуре T.
+func metropolis(delta int, T float64, rng *rand.Rand) bool {
+	if delta <= 0 {
+		// Улучшающее решение принимаем всегда
+		return true
 	}
-	i := rng.Intn(len(p))
-	j := rng.Intn(len(p) - 1)
-	if j >= i {
-		j++
-	}
-	p[i], p[j] = p[j], p[i]
+	// Критерий Метрополиса:
+	// допускает принятие ухудшающих решений
+	return rng.Float64() < math.Exp(-float64(delta)/T)
 }
 
-// Формирует соседнее решение путём извлечения элемента из позиции i и вставки его в позицию j.
-func neighborInsert(p []int, rng *rand.Rand) {
-	n := len(p)
-	if n < 2 {
-		return
-	}
-	i := rng.Intn(n)
-	j := rng.Intn(n - 1)
-	if j >= i {
-		j++
-	}
-
-	// Перемещаем элемент из позиции i в позицию j
-	val := p[i]
-	if i < j {
-		// Сдвиг элементов влево
-		copy(p[i:j], p[i+1:j+1])
-		p[j] = val
-	} else {
-		// Сдвиг элементов вправо
-		copy(p[j+1:i+1], p[j:i])
-		p[j] = val
-	}
+// moves возвращает генератор ходов выбранной окрестности.
+func (s *Solver) moves() *ls.MoveSampler {
+	return ls.NewMoveSampler(ls.MoveKind(s.Cfg.Neighborhood), s.Cfg.BlockMaxLen, s.Cfg.MoveWeights)
 }
@@ -0,0 +1,113 @@
+package sa
+
+import (
+	"math"
+
+	"flowShop/internal/flowshop"
+	"flowShop/internal/ls"
+)
+
+// TempMode — способ задания начальной и конечной температуры.
+type TempMode string
+
+const (
+	// TempFixed — InitialTemp и FinalTemp задаются явно.
+	TempFixed TempMode = "fixed"
+	// TempAcceptance — температуры подбираются по выборке случайных ходов так, чтобы
+	// средняя вероятность принятия ухудшающего хода была равна InitialAcceptance
+	// и FinalAcceptance соответственно.
+	TempAcceptance TempMode = "acceptance"
+	// TempOsmanPotts — T = c·ΣP/(10·n·m) (Osman, Potts, 1989) с c = InitialTempFactor
+	// для начальной и c = FinalTempFactor для конечной температуры.
+	TempOsmanPotts TempMode = "osman-potts"
+)
+
+// minTempBase — нижняя граница базы формулы Османа–Поттса: при нулевых временах
+// обработки все приращения нулевые, и температуры остаются положительными.
+const minTempBase = 1e-6
+
+// calibration — результат выбора температур.
+type calibration struct {
+	t0, tf float64
+	evals  int
+}
+
+// calibrate вычисляет начальную и конечную температуру для экземпляра inst.
+// В режиме TempFixed генератор не используется.
+func (s *Solver) calibrate(inst *flowshop.Instance, eval *flowshop.Evaluator) calibration {
+	switch s.Cfg.TempMode {
+	case TempAcceptance:
+		return s.calibrateAcceptance(inst, eval)
+	case TempOsmanPotts:
+		return osmanPotts(s.Cfg, inst)
+	}
+	return calibration{t0: s.Cfg.InitialTemp, tf: s.Cfg.FinalTemp}
+}
+
+func osmanPotts(cfg Config, inst *flowshop.Instance) calibration {
+	sum := 0
+	for _, p := range inst.ProcTimes {
+		sum += p
+	}
+	base := max(float64(sum)/float64(10*inst.Jobs*inst.Machines), minTempBase)
+	return calibration{
+		t0: cfg.InitialTempFactor * base,
+		tf: cfg.FinalTempFactor * base,
+	}
+}
+
+// calibrateAcceptance собирает CalibrationSamples ухудшающих приращений для ходов
+// из случайных решений и решает уравнение mean(exp(−Δ/T)) = χ относительно T.
+// Если ухудшающих ходов не нашлось, используется формула Османа–Поттса.
+func (s *Solver) calibrateAcceptance(inst *flowshop.Instance, eval *flowshop.Evaluator) calibration {
+	n := inst.Jobs
+	curr := make([]int, n)
+	cand := make([]int, n)
+	ls.InitPermutation(curr)
+
+	moves := s.moves()
+	deltas := make([]float64, 0, s.Cfg.CalibrationSamples)
+	evals := 0
+	for tries := 0; len(deltas) < s.Cfg.CalibrationSamples && tries < 4*s.Cfg.CalibrationSamples; tries++ {
+		ls.ShufflePermutation(curr, s.Rng)
+		copy(cand, curr)
+		moves.Sample(n, s.Rng).Apply(cand)
+		d := eval.MustMakespan(cand) - eval.MustMakespan(curr)
+		evals += 2
+		if d > 0 {
+			deltas = append(deltas, float64(d))
+		}
+	}
+	if len(deltas) == 0 {
+		c := osmanPotts(s.Cfg, inst)
+		c.evals = evals
+		return c
+	}
+	return calibration{
+		t0:    acceptanceTemp(deltas, s.Cfg.InitialAcceptance),
+		tf:    acceptanceTemp(deltas, s.Cfg.FinalAcceptance),
+		evals: evals,
+	}
+}
+
+// acceptanceTemp находит T, при которой средняя вероятность принятия
+// ухудшений deltas равна chi (бисекция по log T; вероятность монотонна по T).
+func acceptanceTemp(deltas []float64, chi float64) float64 {
+	accept := func(t float64) float64 {
+		sum := 0.0
+		for _, d := range deltas {
+			sum += math.Exp(-d / t)
+		}
+		return sum / float64(len(deltas))
+	}
+	lo, hi := math.Log(1e-6), math.Log(1e9)
+	for k := 0; k < 100; k++ {
+		mid := (lo + hi) / 2
+		if accept(math.Exp(mid)) < chi {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return math.Exp((lo + hi) / 2)
+}
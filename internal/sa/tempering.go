@@ -0,0 +1,227 @@
+package sa
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"flowShop/internal/flowshop"
+	"flowShop/internal/ls"
+	"flowShop/internal/opt"
+)
+
+// TemperingSolver — параллельный отжиг (обмен репликами): R реплик выполняют
+// шаги SA при постоянных температурах геометрической лестницы в отдельных горутинах
+// и каждые SwapInterval итераций пытаются обменяться состояниями с соседями по лестнице.
+//
+// Каждая реплика использует собственные оценщик и генератор (сид берётся из Rng),
+// а обмены выполняются в одной горутине генератором Rng, поэтому результат
+// при одинаковом сиде не зависит от планирования горутин.
+type TemperingSolver struct {
+	Cfg TemperingConfig
+	Rng *rand.Rand
+}
+
+func NewTempering(cfg TemperingConfig, rng *rand.Rand) (*TemperingSolver, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if rng == nil {
+		return nil, fmt.Errorf("генератор случайных чисел не инициализирован (nil)")
+	}
+	return &TemperingSolver{Cfg: cfg, Rng: rng}, nil
+}
+
+// replica — состояние одной реплики; температура определяется её местом на лестнице.
+type replica struct {
+	s     *Solver
+	eval  *flowshop.Evaluator
+	moves *ls.MoveSampler
+
+	curr, cand []int
+	cost       int
+
+	best     []int
+	bestCost int
+	evals    int
+}
+
+// anneal выполняет iters шагов Метрополиса при температуре T.
+func (r *replica) anneal(ctx context.Context, iters int, T float64) {
+	for k := 0; k < iters; k++ {
+		if ctx.Err() != nil {
+			return
+		}
+		copy(r.cand, r.curr)
+		r.moves.Sample(len(r.cand), r.s.Rng).Apply(r.cand)
+
+		candCost := r.eval.MustMakespan(r.cand)
+		r.evals++
+
+		if metropolis(candCost-r.cost, T, r.s.Rng) {
+			r.curr, r.cand = r.cand, r.curr
+			r.cost = candCost
+			if r.cost < r.bestCost {
+				r.bestCost = r.cost
+				copy(r.best, r.curr)
+			}
+		}
+	}
+}
+
+// Solve — реализация opt.Optimizer.
+func (s *TemperingSolver) Solve(ctx context.Context, inst *flowshop.Instance) (opt.Result, error) {
+	start := time.Now()
+
+	if err := inst.Validate(); err != nil {
+		return opt.Result{}, err
+	}
+	if err := s.Cfg.Validate(); err != nil {
+		return opt.Result{}, err
+	}
+	if s.Rng == nil {
+		return opt.Result{}, fmt.Errorf("генератор случайных чисел не инициализирован (nil)")
+	}
+
+	eval, err := flowshop.NewEvaluator(inst)
+	if err != nil {
+		return opt.Result{}, err
+	}
+
+	n := inst.Jobs
+	cfg := s.Cfg.SA
+	maxIter := cfg.Iterations
+	if maxIter <= 0 {
+		maxIter = cfg.IterationsPerJob * n
+	}
+
+	// Лестница температур: temps[0] — самая холодная, temps[R-1] — самая горячая
+	calib := (&Solver{Cfg: cfg, Rng: s.Rng}).calibrate(inst, eval)
+	if calib.tf >= calib.t0 {
+		return opt.Result{}, fmt.Errorf(
+			"конечная температура должна быть < начальной (получено %f >= %f)",
+			calib.tf,
+			calib.t0,
+		)
+	}
+	R := s.Cfg.Replicas
+	temps := make([]float64, R)
+	for i := range temps {
+		temps[i] = calib.tf * math.Pow(calib.t0/calib.tf, float64(i)/float64(R-1))
+	}
+
+	// Реплики с собственными генераторами и оценщиками
+	replicas := make([]*replica, R)
+	for i := range replicas {
+		ev, err := flowshop.NewEvaluator(inst)
+		if err != nil {
+			return opt.Result{}, err
+		}
+		r := &replica{
+			s:    &Solver{Cfg: cfg, Rng: rand.New(rand.NewSource(s.Rng.Int63()))},
+			eval: ev,
+			curr: make([]int, n),
+			cand: make([]int, n),
+			best: make([]int, n),
+		}
+		r.moves = r.s.moves()
+		ls.InitPermutation(r.curr)
+		ls.ShufflePermutation(r.curr, r.s.Rng)
+		r.cost = ev.MustMakespan(r.curr)
+		r.evals = 1
+		r.bestCost = r.cost
+		copy(r.best, r.curr)
+		replicas[i] = r
+	}
+
+	// Статистика обменов по парам соседних температур (i, i+1)
+	attempts := make([]int, R-1)
+	accepted := make([]int, R-1)
+
+	iter, phase := 0, 0
+	for iter < maxIter {
+		// Для поддержки отмены через context
+		if err := ctx.Err(); err != nil {
+			res := s.result(replicas, temps, attempts, accepted, calib.evals, iter, map[string]any{"stopped": "context"})
+			res.Duration = time.Since(start)
+			return res, err
+		}
+
+		// Эпоха: реплики независимо выполняют до SwapInterval итераций
+		epoch := min(s.Cfg.SwapInterval, maxIter-iter)
+		var wg sync.WaitGroup
+		for i, r := range replicas {
+			wg.Add(1)
+			go func(r *replica, T float64) {
+				defer wg.Done()
+				r.anneal(ctx, epoch, T)
+			}(r, temps[i])
+		}
+		wg.Wait()
+		iter += epoch
+
+		if iter >= maxIter || ctx.Err() != nil {
+			continue
+		}
+
+		// Обмен состояниями соседних реплик: чётные и нечётные пары поочерёдно.
+		// Вероятность обмена — min(1, exp((1/T_i − 1/T_{i+1})·(E_i − E_{i+1}))).
+		for i := phase; i+1 < R; i += 2 {
+			a, b := replicas[i], replicas[i+1]
+			attempts[i]++
+			d := (1/temps[i] - 1/temps[i+1]) * float64(a.cost-b.cost)
+			if d >= 0 || s.Rng.Float64() < math.Exp(d) {
+				a.curr, b.curr = b.curr, a.curr
+				a.cost, b.cost = b.cost, a.cost
+				accepted[i]++
+			}
+		}
+		phase = 1 - phase
+	}
+
+	res := s.result(replicas, temps, attempts, accepted, calib.evals, maxIter, map[string]any{
+		"replicas":      R,
+		"swap_interval": s.Cfg.SwapInterval,
+		"temp_mode":     string(cfg.TempMode),
+		"neighborhood":  string(cfg.Neighborhood),
+	})
+	res.Duration = time.Since(start)
+	return res, nil
+}
+
+// result собирает лучшее решение по всем репликам
+// (при равенстве — реплики с меньшим номером) и статистику обменов.
+func (s *TemperingSolver) result(replicas []*replica, temps []float64, attempts, accepted []int, calibEvals, iters int, meta map[string]any) opt.Result {
+	best := replicas[0]
+	evals := calibEvals
+	replicaBest := make([]int, len(replicas))
+	for i, r := range replicas {
+		if r.bestCost < best.bestCost {
+			best = r
+		}
+		evals += r.evals
+		replicaBest[i] = r.bestCost
+	}
+
+	rates := make([]float64, len(attempts))
+	for i := range rates {
+		if attempts[i] > 0 {
+			rates[i] = float64(accepted[i]) / float64(attempts[i])
+		}
+	}
+	meta["temperatures"] = temps
+	meta["swap_attempts"] = attempts
+	meta["swap_acceptance"] = rates
+	meta["replica_best"] = replicaBest
+
+	return opt.Result{
+		Permutation: append([]int(nil), best.best...),
+		Makespan:    best.bestCost,
+		Evaluations: evals,
+		Iterations:  iters,
+		Meta:        meta,
+	}
+}
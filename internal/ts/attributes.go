@@ -0,0 +1,225 @@
+package ts
+
+import (
+	"math/rand"
+
+	"flowShop/internal/ls"
+)
+
+// Атрибуты табу. За один запуск используется один вид атрибутов (Config.Attribute):
+//
+//	move:         обратный ход; в режиме critical — порядок пары работ (Nowicki, Smutnicki, 1996):
+//	              при переносе работы x влево запрещается восстановить порядок
+//	              (предшественник x, x), вправо — (x, последователь x);
+//	job-position: возврат перемещённой работы в покинутую позицию;
+//	adjacency:    повторное соседство пары работ, разведённой ходом;
+//	hash:         повторное посещение решения (хеш Зобриста).
+//
+// Для хода вычисляются прямые атрибуты (ход табуирован, если табуирован любой из них)
+// и атрибуты отмены, которые после применения хода добавляются в табу-список.
+
+// pairKey — ключ упорядоченной пары (u, w); старший бит отличает его от ключей ls.Move.
+func pairKey(u, w int) uint64 {
+	return 1<<63 | uint64(uint32(u))<<32 | uint64(uint32(w))
+}
+
+// jobPosKey — ключ пары «работа — позиция».
+func jobPosKey(job, pos int) uint64 {
+	return 1<<63 | 1<<62 | uint64(uint32(job))<<32 | uint64(uint32(pos))
+}
+
+// adjacentKey — ключ неупорядоченной пары соседних работ.
+func adjacentKey(a, b int) uint64 {
+	if a > b {
+		a, b = b, a
+	}
+	return 1<<63 | 1<<61 | uint64(uint32(a))<<32 | uint64(uint32(b))
+}
+
+// newZobrist возвращает случайные ключи z[job·n+pos] хеша Зобриста:
+// хеш перестановки — XOR ключей всех пар «работа — позиция».
+func newZobrist(n int, rng *rand.Rand) []uint64 {
+	z := make([]uint64, n*n)
+	for i := range z {
+		z[i] = rng.Uint64()
+	}
+	return z
+}
+
+// resync пересчитывает позиции работ и хеш после замены текущего решения.
+func (st *search) resync() {
+	st.hash = 0
+	n := len(st.curr)
+	for p, job := range st.curr {
+		st.pos[job] = p
+		if st.zobrist != nil {
+			st.hash ^= st.zobrist[job*n+p]
+		}
+	}
+}
+
+// span возвращает отрезок позиций lo..hi, изменяемых ходом m.
+func span(m ls.Move) (lo, hi int) {
+	lo, hi = min(m.From, m.To), max(m.From, m.To)
+	if m.Kind == ls.MoveBlock {
+		hi += m.Len - 1
+	}
+	return lo, hi
+}
+
+// window возвращает отрезок позиций lo..hi, изменяемых ходом m,
+// и записывает в st.win содержимое этого отрезка после хода.
+func (st *search) window(m ls.Move) (lo, hi int) {
+	lo, hi = span(m)
+	st.win = append(st.win[:0], st.curr[lo:hi+1]...)
+	m.From -= lo
+	m.To -= lo
+	m.Apply(st.win)
+	return lo, hi
+}
+
+// moved вызывает fn для работ, перемещаемых ходом m, с их старой и новой позицией
+// (при вставке — только переносимая работа, при развороте — концы отрезка).
+func (st *search) moved(m ls.Move, fn func(job, from, to int)) {
+	switch m.Kind {
+	case ls.MoveInsert:
+		fn(st.curr[m.From], m.From, m.To)
+	case ls.MoveSwap, ls.MoveReverse:
+		fn(st.curr[m.From], m.From, m.To)
+		fn(st.curr[m.To], m.To, m.From)
+	case ls.MoveBlock:
+		for i := 0; i < m.Len; i++ {
+			fn(st.curr[m.From+i], m.From+i, m.To+i)
+		}
+	}
+}
+
+// candidateHash возвращает хеш решения после хода m.
+func (st *search) candidateHash(m ls.Move) uint64 {
+	n := len(st.curr)
+	lo, _ := st.window(m)
+	h := st.hash
+	for i, job := range st.win {
+		p := lo + i
+		if old := st.curr[p]; old != job {
+			h ^= st.zobrist[old*n+p] ^ st.zobrist[job*n+p]
+		}
+	}
+	return h
+}
+
+// attributes возвращает прямые атрибуты хода m из текущего решения (буфер st.fwd).
+func (st *search) attributes(m ls.Move) []uint64 {
+	st.fwd = st.fwd[:0]
+	switch st.s.Cfg.Attribute {
+	case AttributeJobPosition:
+		st.moved(m, func(job, _, to int) {
+			st.fwd = append(st.fwd, jobPosKey(job, to))
+		})
+	case AttributeAdjacency:
+		// Новые пары соседей на отрезке изменённых позиций и его границах
+		n := len(st.curr)
+		lo, hi := st.window(m)
+		at := func(p int) int {
+			if p >= lo && p <= hi {
+				return st.win[p-lo]
+			}
+			return st.curr[p]
+		}
+		for p := max(lo-1, 0); p <= min(hi, n-2); p++ {
+			a, b := at(p), at(p+1)
+			if d := st.pos[a] - st.pos[b]; d != 1 && d != -1 {
+				st.fwd = append(st.fwd, adjacentKey(a, b))
+			}
+		}
+	case AttributeHash:
+		st.fwd = append(st.fwd, st.candidateHash(m))
+	case AttributeMove:
+		if st.s.Cfg.Search != SearchCritical {
+			st.fwd = append(st.fwd, m.Key(st.curr))
+			break
+		}
+		z := st.curr[m.From]
+		if m.To < m.From {
+			// z переносится перед работами curr[To..From-1]
+			for _, w := range st.curr[m.To:m.From] {
+				st.fwd = append(st.fwd, pairKey(z, w))
+			}
+			break
+		}
+		// z переносится после работ curr[From+1..To]
+		for _, w := range st.curr[m.From+1 : m.To+1] {
+			st.fwd = append(st.fwd, pairKey(w, z))
+		}
+	}
+	return st.fwd
+}
+
+// isTabu сообщает, табуирован ли ход m на итерации iter.
+func (st *search) isTabu(m ls.Move, iter int) bool {
+	for _, k := range st.attributes(m) {
+		if st.tabu.IsTabu(k, iter) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyMove применяет ход m к текущему решению и возвращает атрибуты,
+// запрещающие его отмену (буфер st.undo).
+func (st *search) applyMove(m ls.Move) []uint64 {
+	cfg := st.s.Cfg
+	n := len(st.curr)
+	st.undo = st.undo[:0]
+
+	lo, hi := span(m)
+	hash := st.hash
+	if st.zobrist != nil {
+		hash = st.candidateHash(m)
+	}
+
+	switch cfg.Attribute {
+	case AttributeJobPosition:
+		st.moved(m, func(job, from, _ int) {
+			st.undo = append(st.undo, jobPosKey(job, from))
+		})
+	case AttributeAdjacency:
+		// Пары соседей до хода; после хода остаются только разведённые
+		st.old = st.old[:0]
+		for p := max(lo-1, 0); p <= min(hi, n-2); p++ {
+			st.old = append(st.old, st.curr[p], st.curr[p+1])
+		}
+	case AttributeHash:
+		st.undo = append(st.undo, st.hash)
+	case AttributeMove:
+		if cfg.Search == SearchCritical {
+			z := st.curr[m.From]
+			if m.To < m.From {
+				st.undo = append(st.undo, pairKey(st.curr[m.From-1], z))
+			} else {
+				st.undo = append(st.undo, pairKey(z, st.curr[m.From+1]))
+			}
+		}
+	}
+
+	m.Apply(st.curr)
+	for p := lo; p <= hi; p++ {
+		st.pos[st.curr[p]] = p
+	}
+	st.hash = hash
+
+	switch cfg.Attribute {
+	case AttributeAdjacency:
+		for i := 0; i < len(st.old); i += 2 {
+			a, b := st.old[i], st.old[i+1]
+			if d := st.pos[a] - st.pos[b]; d != 1 && d != -1 {
+				st.undo = append(st.undo, adjacentKey(a, b))
+			}
+		}
+	case AttributeMove:
+		if cfg.Search != SearchCritical {
+			st.undo = append(st.undo, m.Inverse().Key(st.curr))
+		}
+	}
+	return st.undo
+}
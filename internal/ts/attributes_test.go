@@ -0,0 +1,83 @@
+package ts
+
+import (
+	"math/rand"
+	"testing"
+
+	"flowShop/internal/flowshop"
+	"flowShop/internal/ls"
+)
+
+// Применённый ход нельзя сразу отменить: атрибут отмены совпадает с ключами
+// всех равносильных обратных ходов, в том числе в ориентации, которую
+// генерирует полный просмотр окрестности (swap с i < k, insert без to == j−1).
+func TestAppliedMoveIsTabu(t *testing.T) {
+	tests := []struct {
+		name  string
+		neigh Neighborhood
+		move  ls.Move
+		undo  []ls.Move
+	}{
+		{
+			name:  "swap",
+			neigh: NeighborhoodSwap,
+			move:  ls.Move{Kind: ls.MoveSwap, From: 1, To: 4},
+			undo: []ls.Move{
+				{Kind: ls.MoveSwap, From: 1, To: 4},
+				{Kind: ls.MoveSwap, From: 4, To: 1},
+			},
+		},
+		{
+			name:  "adjacent insert",
+			neigh: NeighborhoodInsert,
+			move:  ls.Move{Kind: ls.MoveInsert, From: 2, To: 3},
+			undo: []ls.Move{
+				{Kind: ls.MoveInsert, From: 2, To: 3},
+				{Kind: ls.MoveInsert, From: 3, To: 2},
+			},
+		},
+		{
+			name:  "insert",
+			neigh: NeighborhoodInsert,
+			move:  ls.Move{Kind: ls.MoveInsert, From: 1, To: 5},
+			undo: []ls.Move{
+				{Kind: ls.MoveInsert, From: 5, To: 1},
+			},
+		},
+	}
+
+	inst := flowshop.RandomInstance(8, 3, 1, 99, rand.New(rand.NewSource(1)))
+	eval, err := flowshop.NewEvaluator(inst)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Search = SearchFull
+			cfg.Neighborhood = tt.neigh
+			cfg.Window = inst.Jobs
+			s, err := New(cfg, rand.New(rand.NewSource(1)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			st, err := s.newSearch(inst, eval)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ls.InitPermutation(st.curr)
+			st.resync()
+
+			const iter = 0
+			for _, k := range st.applyMove(tt.move) {
+				st.tabu.Add(k, iter+cfg.TabuTenure)
+			}
+			for _, m := range tt.undo {
+				if !st.isTabu(m, iter+1) {
+					t.Errorf("ход %+v, отменяющий %+v, не табуирован", m, tt.move)
+				}
+			}
+		})
+	}
+}
@@ -1,13 +1,59 @@
 package ts
 
-import "fmt"
+import (
+	"fmt"
 
-// Neighborhood определяет тип окрестности.
+	"flowShop/internal/ls"
+)
+
+// Neighborhood определяет тип окрестности (см. ls.MoveKind).
 type Neighborhood string
 
 const (
-	NeighborhoodInsert Neighborhood = "insert"
-	NeighborhoodSwap   Neighborhood = "swap"
+	NeighborhoodInsert  Neighborhood = "insert"
+	NeighborhoodSwap    Neighborhood = "swap"
+	NeighborhoodBlock   Neighborhood = "block"
+	NeighborhoodReverse Neighborhood = "reverse"
+	NeighborhoodMixed   Neighborhood = "mixed"
+)
+
+// SearchMode определяет способ построения окрестности.
+type SearchMode string
+
+const (
+	// SearchSampled — NeighborsPerIter случайных ходов окрестности Neighborhood.
+	SearchSampled SearchMode = "sampled"
+	// SearchCritical — вставки на границы критических блоков и за них (в духе TSAB)
+	// с ускорением Тайара; Neighborhood и NeighborsPerIter не используются.
+	SearchCritical SearchMode = "critical"
+	// SearchFull — детерминированный просмотр всей окрестности insert или swap
+	// с инкрементальной оценкой; окрестность сужается списком кандидатов и окном позиций.
+	SearchFull SearchMode = "full"
+)
+
+// TabuAttribute определяет атрибут, запрещаемый после хода.
+type TabuAttribute string
+
+const (
+	// AttributeMove — обратный ход; в режиме critical — порядок пары работ (TSAB).
+	AttributeMove TabuAttribute = "move"
+	// AttributeJobPosition — возврат перемещённой работы в покинутую позицию.
+	AttributeJobPosition TabuAttribute = "job-position"
+	// AttributeAdjacency — повторное соседство пары работ, разведённой ходом.
+	AttributeAdjacency TabuAttribute = "adjacency"
+	// AttributeHash — повторное посещение решения (хеш Зобриста).
+	AttributeHash TabuAttribute = "hash"
+)
+
+// CandidateList определяет список кандидатов полного просмотра окрестности.
+type CandidateList string
+
+const (
+	// CandidatesAll — все ходы окрестности.
+	CandidatesAll CandidateList = "all"
+	// CandidatesCritical — исключаются ходы, обе позиции которых лежат внутри
+	// (не на границах) одного критического блока: такие ходы не улучшают makespan.
+	CandidatesCritical CandidateList = "critical"
 )
 
 type Config struct {
@@ -20,7 +66,55 @@ type Config struct {
 
 	NeighborsPerIter int
 
+	// Attribute — вид атрибутов табу
+	Attribute TabuAttribute
+
+	// Реактивный табу-поиск: срок табу увеличивается в ReactiveIncrease раз при повторном
+	// посещении решения (не более ReactiveMaxTenure) и уменьшается в ReactiveDecrease раз
+	// после ReactiveInterval итераций без повторов
+	Reactive          bool
+	ReactiveIncrease  float64
+	ReactiveDecrease  float64
+	ReactiveInterval  int
+	ReactiveMaxTenure int
+
 	Neighborhood Neighborhood
+	// BlockMaxLen — максимальная длина переносимого блока (окрестности block и mixed)
+	BlockMaxLen int
+	// MoveWeights — веса видов хода окрестности mixed
+	MoveWeights ls.MoveWeights
+
+	// Search — способ построения окрестности
+	Search SearchMode
+	// Candidates — список кандидатов режима full
+	Candidates CandidateList
+	// Window — максимальное расстояние между позициями хода в режиме full (0 — без ограничения);
+	// для окрестности swap обязательно: обмен оценивается за O(Window·m), а не за O(1)
+	Window int
+
+	// EliteSize — число хранимых рекордных решений для возврата (0 — без возврата)
+	EliteSize int
+	// BackJumpAfter — число итераций без улучшения, после которого поиск
+	// возвращается к последнему элитному решению (0 — без возврата)
+	BackJumpAfter int
+
+	// Долговременная память: частоты пар «работа — позиция»
+	// FrequencyPenalty — коэффициент частотного штрафа хода в фазе диверсификации
+	// (в долях лучшего makespan; 0 — без диверсификации)
+	FrequencyPenalty float64
+	// DiversifyAfter — число итераций без улучшения до начала фазы диверсификации
+	DiversifyAfter int
+	// DiversifyLength — максимальная длина фазы диверсификации в итерациях
+	DiversifyLength int
+
+	// ArchiveSize — размер архива лучших различных решений (0 — без архива)
+	ArchiveSize int
+	// IntensifyAfter — число итераций без улучшения, после которого поиск
+	// перезапускается со случайного решения архива (0 — без интенсификации)
+	IntensifyAfter int
+	// RestartAfter — число итераций без улучшения, после которого поиск
+	// перезапускается со случайной перестановки (0 — без перезапусков)
+	RestartAfter int
 }
 
 func DefaultConfig() Config {
@@ -32,7 +126,30 @@ func DefaultConfig() Config {
 		TabuTenureRand: 3,
 
 		NeighborsPerIter: 90,
-		Neighborhood:     NeighborhoodInsert,
+		Attribute:        AttributeMove,
+
+		Reactive:          false,
+		ReactiveIncrease:  1.2,
+		ReactiveDecrease:  0.9,
+		ReactiveInterval:  100,
+		ReactiveMaxTenure: 50,
+
+		Neighborhood: NeighborhoodInsert,
+		BlockMaxLen:  3,
+		MoveWeights:  ls.DefaultMoveWeights(),
+
+		Search:        SearchSampled,
+		Candidates:    CandidatesAll,
+		Window:        0,
+		EliteSize:     0,
+		BackJumpAfter: 0,
+
+		FrequencyPenalty: 0,
+		DiversifyAfter:   0,
+		DiversifyLength:  0,
+		ArchiveSize:      0,
+		IntensifyAfter:   0,
+		RestartAfter:     0,
 	}
 }
 
@@ -60,14 +177,138 @@ func (c Config) Validate() error {
 			c.NeighborsPerIter,
 		)
 	}
-	switch c.Neighborhood {
-	case NeighborhoodInsert, NeighborhoodSwap:
+	switch c.Attribute {
+	case AttributeMove, AttributeJobPosition, AttributeAdjacency, AttributeHash:
+		// ok
+	default:
+		return fmt.Errorf(
+			"неизвестный атрибут табу %q",
+			c.Attribute,
+		)
+	}
+	if c.Reactive {
+		if c.ReactiveIncrease <= 1 {
+			return fmt.Errorf(
+				"ReactiveIncrease должно быть > 1 (получено %f)",
+				c.ReactiveIncrease,
+			)
+		}
+		if c.ReactiveDecrease <= 0 || c.ReactiveDecrease >= 1 {
+			return fmt.Errorf(
+				"ReactiveDecrease должно быть в (0, 1) (получено %f)",
+				c.ReactiveDecrease,
+			)
+		}
+		if c.ReactiveInterval <= 0 {
+			return fmt.Errorf(
+				"ReactiveInterval должно быть > 0 (получено %d)",
+				c.ReactiveInterval,
+			)
+		}
+		if c.ReactiveMaxTenure < c.TabuTenure {
+			return fmt.Errorf(
+				"ReactiveMaxTenure должно быть >= TabuTenure (получено %d < %d)",
+				c.ReactiveMaxTenure,
+				c.TabuTenure,
+			)
+		}
+	}
+	switch c.Search {
+	case SearchSampled, SearchCritical:
 		// ok
+	case SearchFull:
+		if c.Neighborhood != NeighborhoodInsert && c.Neighborhood != NeighborhoodSwap {
+			return fmt.Errorf(
+				"полный просмотр поддерживает только окрестности insert и swap (получено %q)",
+				c.Neighborhood,
+			)
+		}
+		switch c.Candidates {
+		case CandidatesAll, CandidatesCritical:
+			// ok
+		default:
+			return fmt.Errorf(
+				"неизвестный список кандидатов %q",
+				c.Candidates,
+			)
+		}
+		if c.Window < 0 {
+			return fmt.Errorf(
+				"Window должно быть >= 0 (получено %d)",
+				c.Window,
+			)
+		}
+		if c.Neighborhood == NeighborhoodSwap && c.Window == 0 {
+			return fmt.Errorf("полный просмотр окрестности swap требует окна позиций Window > 0")
+		}
 	default:
 		return fmt.Errorf(
-			"неизвестный тип окрестности %q",
-			c.Neighborhood,
+			"неизвестный способ построения окрестности %q",
+			c.Search,
+		)
+	}
+	if c.EliteSize < 0 {
+		return fmt.Errorf(
+			"EliteSize должно быть >= 0 (получено %d)",
+			c.EliteSize,
+		)
+	}
+	if c.BackJumpAfter < 0 {
+		return fmt.Errorf(
+			"BackJumpAfter должно быть >= 0 (получено %d)",
+			c.BackJumpAfter,
+		)
+	}
+	if c.FrequencyPenalty < 0 {
+		return fmt.Errorf(
+			"FrequencyPenalty должно быть >= 0 (получено %f)",
+			c.FrequencyPenalty,
+		)
+	}
+	if c.DiversifyAfter < 0 || c.IntensifyAfter < 0 || c.RestartAfter < 0 {
+		return fmt.Errorf(
+			"DiversifyAfter, IntensifyAfter и RestartAfter должны быть >= 0 (получено %d, %d, %d)",
+			c.DiversifyAfter,
+			c.IntensifyAfter,
+			c.RestartAfter,
 		)
 	}
-	return nil
+	if c.FrequencyPenalty > 0 && c.DiversifyAfter > 0 && c.DiversifyLength <= 0 {
+		return fmt.Errorf(
+			"DiversifyLength должно быть > 0 (получено %d)",
+			c.DiversifyLength,
+		)
+	}
+	if c.ArchiveSize < 0 {
+		return fmt.Errorf(
+			"ArchiveSize должно быть >= 0 (получено %d)",
+			c.ArchiveSize,
+		)
+	}
+	if c.BackJumpAfter > 0 && c.EliteSize == 0 {
+		return fmt.Errorf("для возврата к элитным решениям (BackJumpAfter > 0) требуется EliteSize > 0")
+	}
+	if c.IntensifyAfter > 0 && c.ArchiveSize == 0 {
+		return fmt.Errorf("для интенсификации (IntensifyAfter > 0) требуется ArchiveSize > 0")
+	}
+	return ls.ValidateMoves(ls.MoveKind(c.Neighborhood), c.BlockMaxLen, c.MoveWeights)
+}
+
+// maxTenure — наибольший базовый срок табу (без случайной добавки).
+func (c Config) maxTenure() int {
+	if c.Reactive {
+		return c.ReactiveMaxTenure
+	}
+	return c.TabuTenure
+}
+
+// attributesPerMove — наибольшее число атрибутов отмены одного хода.
+func (c Config) attributesPerMove() int {
+	switch c.Attribute {
+	case AttributeJobPosition:
+		return max(2, c.BlockMaxLen)
+	case AttributeAdjacency:
+		return 4
+	}
+	return 1
 }
@@ -0,0 +1,60 @@
+package ts
+
+// eliteEntry — элитное решение и атрибуты хода, сделанного из него после нахождения.
+type eliteEntry struct {
+	perm  []int
+	cost  int
+	first []uint64
+}
+
+// eliteList — ограниченный стек последних рекордных решений для возврата
+// (back-jump tracking в духе TSAB). При переполнении вытесняется самое старое.
+type eliteList struct {
+	entries []eliteEntry
+	size    int
+
+	// pending — ожидается ход из только что добавленного решения
+	pending bool
+}
+
+func newEliteList(size int) *eliteList {
+	return &eliteList{entries: make([]eliteEntry, 0, size), size: size}
+}
+
+// push сохраняет копию рекордного решения.
+func (l *eliteList) push(perm []int, cost int) {
+	if l.size == 0 {
+		return
+	}
+	var buf []int
+	if len(l.entries) == l.size {
+		// Буфер самого старого решения переиспользуется
+		buf = l.entries[0].perm
+		copy(l.entries, l.entries[1:])
+		l.entries = l.entries[:len(l.entries)-1]
+	}
+	buf = append(buf[:0], perm...)
+	l.entries = append(l.entries, eliteEntry{perm: buf, cost: cost})
+	l.pending = true
+}
+
+// recordFirst запоминает атрибуты хода, сделанного из последнего добавленного решения.
+func (l *eliteList) recordFirst(keys []uint64) {
+	if !l.pending {
+		return
+	}
+	top := &l.entries[len(l.entries)-1]
+	top.first = append([]uint64(nil), keys...)
+	l.pending = false
+}
+
+// pop извлекает последнее добавленное решение.
+func (l *eliteList) pop() (eliteEntry, bool) {
+	if len(l.entries) == 0 {
+		return eliteEntry{}, false
+	}
+	e := l.entries[len(l.entries)-1]
+	l.entries = l.entries[:len(l.entries)-1]
+	l.pending = false
+	return e, true
+}
@@ -0,0 +1,135 @@
+package ts
+
+import (
+	"math/rand"
+	"slices"
+)
+
+// memory — долговременная память табу-поиска: частоты пар «работа — позиция»
+// для штрафов в фазах диверсификации и архив элитных решений для интенсификации.
+type memory struct {
+	cfg Config
+	n   int
+
+	// freq[job·n+pos] — число итераций, на которых работа job стояла в позиции pos
+	freq  []int
+	iters int
+
+	diversifying bool
+	phaseEnd     int
+
+	archive []archived
+
+	// Итерации последнего срабатывания каждого механизма
+	lastDiversify, lastIntensify, lastRestart, lastJump int
+
+	diversifications, intensifications, restarts int
+}
+
+type archived struct {
+	perm []int
+	cost int
+}
+
+func newMemory(cfg Config, n int) *memory {
+	m := &memory{cfg: cfg, n: n}
+	if cfg.FrequencyPenalty > 0 && cfg.DiversifyAfter > 0 {
+		m.freq = make([]int, n*n)
+	}
+	return m
+}
+
+// stalled сообщает, прошло ли after итераций с последнего улучшения
+// и последнего срабатывания механизма (after == 0 — механизм выключен).
+func stalled(iter, lastImprove, lastEvent, after int) bool {
+	return after > 0 && iter-max(lastImprove, lastEvent) >= after
+}
+
+// record учитывает текущее решение в частотах и архиве.
+func (m *memory) record(curr []int, cost int) {
+	if m.freq != nil {
+		for pos, job := range curr {
+			m.freq[job*m.n+pos]++
+		}
+		m.iters++
+	}
+	if m.cfg.ArchiveSize > 0 {
+		m.archivePut(curr, cost)
+	}
+}
+
+// archivePut добавляет решение в архив, если оно отличается от хранимых и лучше худшего
+// из них (или архив не заполнен); вытесняется худшее решение.
+func (m *memory) archivePut(perm []int, cost int) {
+	worst := -1
+	if len(m.archive) == m.cfg.ArchiveSize {
+		worst = 0
+		for i, a := range m.archive {
+			if a.cost > m.archive[worst].cost {
+				worst = i
+			}
+		}
+		if cost >= m.archive[worst].cost {
+			return
+		}
+	}
+	for _, a := range m.archive {
+		if a.cost == cost && slices.Equal(a.perm, perm) {
+			return
+		}
+	}
+	if worst < 0 {
+		m.archive = append(m.archive, archived{perm: slices.Clone(perm), cost: cost})
+		return
+	}
+	copy(m.archive[worst].perm, perm)
+	m.archive[worst].cost = cost
+}
+
+// pickElite возвращает случайное решение архива.
+func (m *memory) pickElite(rng *rand.Rand) (archived, bool) {
+	if len(m.archive) == 0 {
+		return archived{}, false
+	}
+	return m.archive[rng.Intn(len(m.archive))], true
+}
+
+// updatePhase начинает или завершает фазу диверсификации.
+func (m *memory) updatePhase(iter, lastImprove int) {
+	if m.freq == nil {
+		return
+	}
+	if m.diversifying {
+		if iter >= m.phaseEnd || lastImprove == iter-1 {
+			m.diversifying = false
+			m.lastDiversify = iter
+		}
+		return
+	}
+	if stalled(iter, lastImprove, m.lastDiversify, m.cfg.DiversifyAfter) {
+		m.diversifying = true
+		m.phaseEnd = iter + m.cfg.DiversifyLength
+		m.diversifications++
+	}
+}
+
+// penalty — штраф за постановку работы job в позицию pos в фазе диверсификации:
+// FrequencyPenalty · bestCost · (доля итераций, на которых job стояла в pos).
+func (m *memory) penalty(job, pos, bestCost int) int {
+	if !m.diversifying || m.iters == 0 {
+		return 0
+	}
+	f := float64(m.freq[job*m.n+pos]) / float64(m.iters)
+	return int(m.cfg.FrequencyPenalty*float64(bestCost)*f + 0.5)
+}
+
+// meta дополняет Meta статистикой долговременной памяти.
+func (m *memory) meta(meta map[string]any) {
+	meta["diversifications"] = m.diversifications
+	meta["intensifications"] = m.intensifications
+	meta["restarts"] = m.restarts
+	meta["archive_size"] = len(m.archive)
+	if m.freq != nil {
+		meta["frequency_penalty"] = m.cfg.FrequencyPenalty
+	}
+}
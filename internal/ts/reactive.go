@@ -0,0 +1,47 @@
+package ts
+
+// reactive — реактивная настройка срока табу (Battiti, Tecchiolli, 1994): каждое решение
+// запоминается по хешу Зобриста; повторное посещение говорит о зацикливании, и срок
+// увеличивается в ReactiveIncrease раз, а после ReactiveInterval итераций без повторов
+// уменьшается в ReactiveDecrease раз.
+type reactive struct {
+	cfg    Config
+	tenure int
+
+	visits     map[uint64]int // хеш решения → итерация последнего посещения
+	lastChange int
+
+	repetitions, increases, decreases int
+}
+
+func newReactive(cfg Config) *reactive {
+	return &reactive{
+		cfg:    cfg,
+		tenure: cfg.TabuTenure,
+		visits: make(map[uint64]int),
+	}
+}
+
+// observe регистрирует посещение решения с хешем h на итерации iter.
+func (r *reactive) observe(h uint64, iter int) {
+	if _, ok := r.visits[h]; ok {
+		r.repetitions++
+		next := int(float64(r.tenure)*r.cfg.ReactiveIncrease + 0.5)
+		r.tenure = min(max(next, r.tenure+1), r.cfg.ReactiveMaxTenure)
+		r.lastChange = iter
+		r.increases++
+	} else if iter-r.lastChange >= r.cfg.ReactiveInterval && r.tenure > 1 {
+		r.tenure = max(int(float64(r.tenure)*r.cfg.ReactiveDecrease), 1)
+		r.lastChange = iter
+		r.decreases++
+	}
+	r.visits[h] = iter
+}
+
+// meta дополняет Meta состоянием реактивной настройки.
+func (r *reactive) meta(meta map[string]any) {
+	meta["tabu_tenure_final"] = r.tenure
+	meta["repetitions"] = r.repetitions
+	meta["tenure_increases"] = r.increases
+	meta["tenure_decreases"] = r.decreases
+}
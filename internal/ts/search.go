@@ -0,0 +1,373 @@
+package ts
+
+import (
+	"flowShop/internal/flowshop"
+	"flowShop/internal/ls"
+)
+
+// search — состояние одного запуска табу-поиска, общее для способов
+// построения окрестности.
+type search struct {
+	s    *Solver
+	eval *flowshop.Evaluator
+
+	curr, cand []int
+	tabu       *tabuList
+	mem        *memory
+	bestCost   int
+	evals      int
+
+	// Случайная выборка соседей
+	moves *ls.MoveSampler
+
+	// Окрестность критических блоков
+	ins    *flowshop.InsertionEvaluator
+	blocks []flowshop.Block
+	rest   []int
+	out    []int
+	mark   []int
+	stamp  int
+
+	// Полный просмотр окрестности
+	segs     *ls.SegmentEvaluator
+	seg      []int
+	interior []int
+
+	// Атрибуты табу: позиции работ, окно изменённых позиций хода,
+	// буферы атрибутов и хеш Зобриста текущего решения
+	pos       []int
+	win       []int
+	fwd, undo []uint64
+	old       []int
+	zobrist   []uint64
+	hash      uint64
+	reactive  *reactive
+}
+
+func (s *Solver) newSearch(inst *flowshop.Instance, eval *flowshop.Evaluator) (*search, error) {
+	n := inst.Jobs
+	st := &search{
+		s:    s,
+		eval: eval,
+		curr: make([]int, n),
+		cand: make([]int, n),
+
+		// Табу-список - кольцевой буфер с мапой
+		// Ёмкость выбирается с запасом относительно длины табу и числа атрибутов хода
+		tabu: newTabuList(max(32, (s.Cfg.maxTenure()+s.Cfg.TabuTenureRand)*4*s.Cfg.attributesPerMove())),
+		mem:  newMemory(s.Cfg, n),
+		pos:  make([]int, n),
+		win:  make([]int, 0, n),
+	}
+	if s.Cfg.Attribute == AttributeHash || s.Cfg.Reactive {
+		st.zobrist = newZobrist(n, s.Rng)
+	}
+	if s.Cfg.Reactive {
+		st.reactive = newReactive(s.Cfg)
+	}
+
+	switch s.Cfg.Search {
+	case SearchCritical:
+		ins, err := flowshop.NewInsertionEvaluator(inst)
+		if err != nil {
+			return nil, err
+		}
+		st.ins = ins
+		st.rest = make([]int, 0, n)
+		st.out = make([]int, n)
+		st.mark = make([]int, n)
+	case SearchFull:
+		ins, err := flowshop.NewInsertionEvaluator(inst)
+		if err != nil {
+			return nil, err
+		}
+		segs, err := ls.NewSegmentEvaluator(inst)
+		if err != nil {
+			return nil, err
+		}
+		st.ins = ins
+		st.segs = segs
+		st.rest = make([]int, 0, n)
+		st.out = make([]int, n)
+		st.seg = make([]int, 0, n)
+		st.interior = make([]int, n)
+	default:
+		// Генератор соседних решений
+		st.moves = ls.NewMoveSampler(ls.MoveKind(s.Cfg.Neighborhood), s.Cfg.BlockMaxLen, s.Cfg.MoveWeights)
+	}
+	return st, nil
+}
+
+// tenure возвращает базовый срок табу (без случайной добавки).
+func (st *search) tenure() int {
+	if st.reactive != nil {
+		return st.reactive.tenure
+	}
+	return st.s.Cfg.TabuTenure
+}
+
+// sampledMove оценивает NeighborsPerIter случайных ходов и возвращает лучший
+// допустимый (или лучший табуированный, если допустимых нет) и его makespan.
+// Ходы сравниваются с учётом частотного штрафа фазы диверсификации.
+func (st *search) sampledMove(iter int) (ls.Move, int) {
+	s := st.s
+	n := len(st.curr)
+
+	// Лучший допустимый ход
+	bestMove := ls.Move{}
+	bestMoveCost, bestMoveScore := maxInt, maxInt
+
+	// Запасной ход (лучший без учёта табу),
+	// используется если все допустимые ходы табуированы
+	fallbackMove := ls.Move{}
+	fallbackCost, fallbackScore := maxInt, maxInt
+
+	// Итерация по случайно сгенерированным соседям
+	for k := 0; k < max(s.Cfg.NeighborsPerIter, 1); k++ {
+		m := st.moves.Sample(n, s.Rng)
+
+		// Формирование соседнего решения
+		copy(st.cand, st.curr)
+		m.Apply(st.cand)
+
+		cost := st.eval.MustMakespan(st.cand)
+		st.evals++
+		score := cost + st.penalty(m)
+
+		// Обновление хода
+		if score < fallbackScore {
+			fallbackCost, fallbackScore = cost, score
+			fallbackMove = m
+		}
+
+		if score >= bestMoveScore {
+			continue
+		}
+
+		// Табуированный ход пропускается,
+		// если не выполняется критерий аспирации
+		aspiration := cost < st.bestCost
+		if !aspiration && st.isTabu(m, iter) {
+			continue
+		}
+
+		bestMoveCost, bestMoveScore = cost, score
+		bestMove = m
+	}
+
+	if bestMoveCost == maxInt {
+		return fallbackMove, fallbackCost
+	}
+	return bestMove, bestMoveCost
+}
+
+// criticalMove просматривает окрестность критических блоков (Nowicki, Smutnicki, 1996):
+// работа блока вставляется только на его границы или за них — в пределы соседнего
+// блока критического пути. Ходы внутри блока, не затрагивающие его границ, makespan
+// не улучшают. Для каждой работы все разрешённые позиции оцениваются одним вызовом
+// ускорения Тайара. Для гибридного flow-shop разрешены все позиции.
+func (st *search) criticalMove(iter int) (ls.Move, int) {
+	n := len(st.curr)
+	st.blocks = st.eval.CriticalBlocks(st.curr, st.blocks)
+	full := len(st.blocks) == 0
+
+	bestMove, bestMoveCost, bestMoveScore := ls.Move{}, maxInt, maxInt
+	fallbackMove, fallbackCost, fallbackScore := ls.Move{}, maxInt, maxInt
+
+	bi := 0
+	for j := 0; j < n; j++ {
+		st.stamp++
+		marked := false
+		if full {
+			marked = st.markRange(0, n-1, j)
+		} else {
+			// Блоки, содержащие позицию j (не более двух соседних)
+			for st.blocks[bi].End < j {
+				bi++
+			}
+			for b := bi; b < len(st.blocks) && st.blocks[b].Start <= j; b++ {
+				marked = st.markTargets(b, j) || marked
+			}
+		}
+		if !marked {
+			continue
+		}
+
+		// Makespan вставки работы curr[j] во все позиции остальной последовательности
+		job := st.curr[j]
+		st.rest = append(st.rest[:0], st.curr[:j]...)
+		st.rest = append(st.rest, st.curr[j+1:]...)
+		st.ins.InsertionMakespans(st.rest, job, st.out)
+
+		for to := 0; to < n; to++ {
+			if st.mark[to] != st.stamp {
+				continue
+			}
+			cost := st.out[to]
+			st.evals++
+
+			m := ls.Move{Kind: ls.MoveInsert, From: j, To: to}
+			score := cost + st.penalty(m)
+			if score < fallbackScore {
+				fallbackMove, fallbackCost, fallbackScore = m, cost, score
+			}
+			if score >= bestMoveScore {
+				continue
+			}
+			if cost >= st.bestCost && st.isTabu(m, iter) {
+				continue
+			}
+			bestMove, bestMoveCost, bestMoveScore = m, cost, score
+		}
+	}
+
+	if bestMoveCost == maxInt {
+		return fallbackMove, fallbackCost
+	}
+	return bestMove, bestMoveCost
+}
+
+// fullMove просматривает всю окрестность insert или swap без повторов и возвращает
+// лучший допустимый ход (или лучший табуированный, если допустимых нет) и его makespan.
+// Вставки работы во все позиции оцениваются одним вызовом ускорения Тайара, так что
+// просмотр окрестности insert стоит O(n²·m). Обмен (i, k) оценивается по головам и хвостам
+// текущего решения за O((k−i)·m), поэтому окрестность swap просматривается только
+// в окне Window (Config.Validate) за O(n·Window²·m).
+func (st *search) fullMove(iter int) (ls.Move, int) {
+	s := st.s
+	n := len(st.curr)
+	st.markInterior()
+
+	bestMove, bestMoveCost, bestMoveScore := ls.Move{}, maxInt, maxInt
+	fallbackMove, fallbackCost, fallbackScore := ls.Move{}, maxInt, maxInt
+
+	consider := func(m ls.Move, cost int) {
+		st.evals++
+		score := cost + st.penalty(m)
+		if score < fallbackScore {
+			fallbackMove, fallbackCost, fallbackScore = m, cost, score
+		}
+		if score >= bestMoveScore {
+			return
+		}
+		if cost >= st.bestCost && st.isTabu(m, iter) {
+			return
+		}
+		bestMove, bestMoveCost, bestMoveScore = m, cost, score
+	}
+
+	if s.Cfg.Neighborhood == NeighborhoodSwap {
+		st.segs.Prepare(st.curr)
+		for i := 0; i < n-1; i++ {
+			hi := n - 1
+			if s.Cfg.Window > 0 {
+				hi = min(hi, i+s.Cfg.Window)
+			}
+			for k := i + 1; k <= hi; k++ {
+				if !st.candidate(i, k) {
+					continue
+				}
+				st.seg = append(st.seg[:0], st.curr[i:k+1]...)
+				st.seg[0], st.seg[k-i] = st.seg[k-i], st.seg[0]
+				consider(ls.Move{Kind: ls.MoveSwap, From: i, To: k}, st.segs.Makespan(st.curr, i, k, st.seg))
+			}
+		}
+	} else {
+		for j := 0; j < n; j++ {
+			lo, hi := 0, n-1
+			if s.Cfg.Window > 0 {
+				lo, hi = max(lo, j-s.Cfg.Window), min(hi, j+s.Cfg.Window)
+			}
+			evaluated := false
+			for to := lo; to <= hi; to++ {
+				// Вставка в позицию j−1 совпадает с вставкой работы j−1 в позицию j
+				if to == j || to == j-1 || !st.candidate(j, to) {
+					continue
+				}
+				if !evaluated {
+					// Makespan вставки работы curr[j] во все позиции остальной последовательности
+					st.rest = append(st.rest[:0], st.curr[:j]...)
+					st.rest = append(st.rest, st.curr[j+1:]...)
+					st.ins.InsertionMakespans(st.rest, st.curr[j], st.out)
+					evaluated = true
+				}
+				consider(ls.Move{Kind: ls.MoveInsert, From: j, To: to}, st.out[to])
+			}
+		}
+	}
+
+	if bestMoveCost == maxInt {
+		return fallbackMove, fallbackCost
+	}
+	return bestMove, bestMoveCost
+}
+
+// markInterior отмечает для списка кандидатов critical позиции внутри критических блоков:
+// interior[p] — номер блока, внутри которого лежит позиция p, или −1.
+func (st *search) markInterior() {
+	if st.s.Cfg.Candidates != CandidatesCritical {
+		return
+	}
+	for p := range st.interior {
+		st.interior[p] = -1
+	}
+	st.blocks = st.eval.CriticalBlocks(st.curr, st.blocks)
+	for b, blk := range st.blocks {
+		for p := blk.Start + 1; p < blk.End; p++ {
+			st.interior[p] = b
+		}
+	}
+}
+
+// candidate сообщает, входит ли ход между позициями from и to в список кандидатов.
+// Перестановка работ внутри критического блока сохраняет критический путь, поэтому
+// ходы, обе позиции которых лежат внутри одного блока, makespan не улучшают.
+func (st *search) candidate(from, to int) bool {
+	if st.s.Cfg.Candidates != CandidatesCritical {
+		return true
+	}
+	return st.interior[from] < 0 || st.interior[from] != st.interior[to]
+}
+
+// penalty — частотный штраф хода m: работа из позиции From ставится в позицию To
+// (при обмене — и работа из To в From).
+func (st *search) penalty(m ls.Move) int {
+	if !st.mem.diversifying {
+		return 0
+	}
+	p := st.mem.penalty(st.curr[m.From], m.To, st.bestCost)
+	if m.Kind == ls.MoveSwap {
+		p += st.mem.penalty(st.curr[m.To], m.From, st.bestCost)
+	}
+	return p
+}
+
+// markTargets отмечает позиции вставки работы из позиции j блока b: от начала
+// предыдущего блока до начала блока b и от конца блока b до конца следующего.
+// Возвращает true, если отмечена хотя бы одна позиция.
+func (st *search) markTargets(b, j int) bool {
+	blk := st.blocks[b]
+	left, right := blk.Start, blk.End
+	if b > 0 {
+		left = st.blocks[b-1].Start
+	}
+	if b+1 < len(st.blocks) {
+		right = st.blocks[b+1].End
+	}
+
+	l := st.markRange(left, blk.Start, j)
+	r := st.markRange(blk.End, right, j)
+	return l || r
+}
+
+// markRange отмечает позиции lo..hi, кроме j.
+func (st *search) markRange(lo, hi, j int) bool {
+	marked := false
+	for to := lo; to <= hi; to++ {
+		if to != j {
+			st.mark[to] = st.stamp
+			marked = true
+		}
+	}
+	return marked
+}
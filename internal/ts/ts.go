@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"flowShop/internal/flowshop"
+	"flowShop/internal/ls"
 	"flowShop/internal/opt"
 )
 
@@ -59,167 +60,162 @@ func (s *Solver) Solve(ctx context.Context, inst *flowshop.Instance) (opt.Result
 		maxIter = s.Cfg.IterationsPerJob * n
 	}
 
-	// Текущее и кандидатное решения
-	curr := make([]int, n)
-	cand := make([]int, n)
+	// Текущее решение и состояние поиска
+	st, err := s.newSearch(inst, eval)
+	if err != nil {
+		return opt.Result{}, err
+	}
+	curr := st.curr
 
 	// Инициализация начального решения
-	initPermutation(curr)
-	shufflePermutation(curr, s.Rng)
+	ls.InitPermutation(curr)
+	ls.ShufflePermutation(curr, s.Rng)
+	st.resync()
 
 	currCost := eval.MustMakespan(curr)
-	evals := 1
+	st.evals = 1
 
 	// Глобально лучшее решение
 	best := make([]int, n)
 	copy(best, curr)
-	bestCost := currCost
+	st.bestCost = currCost
 
-	// Табу-список - кольцевой буфер с мапой
-	// Ёмкость выбирается с запасом относительно длины табу
-	tabu := newTabuList(max(32, (s.Cfg.TabuTenure+s.Cfg.TabuTenureRand)*4))
-
-	neighbors := s.Cfg.NeighborsPerIter
-	if neighbors < 1 {
-		neighbors = 1
-	}
+	// Элитные решения для возврата (back-jump) при застое
+	elite := newEliteList(s.Cfg.EliteSize)
+	mem := st.mem
+	lastImprove, backJumps := 0, 0
 
 	for iter := 0; iter < maxIter; iter++ {
 		// Для поддержки отмены через context
 		if err := ctx.Err(); err != nil {
+			meta := map[string]any{
+				"stopped":    "context",
+				"back_jumps": backJumps,
+			}
+			mem.meta(meta)
+			if st.reactive != nil {
+				st.reactive.meta(meta)
+			}
 			return opt.Result{
 				Permutation: best,
-				Makespan:    bestCost,
-				Evaluations: evals,
+				Makespan:    st.bestCost,
+				Evaluations: st.evals,
 				Iterations:  iter,
 				Duration:    time.Since(start),
-				Meta: map[string]any{
-					"stopped": "context",
-				},
+				Meta:        meta,
 			}, err
 		}
 
-		// Лучший допустимый ход
-		bestMoveFrom, bestMoveTo := -1, -1
-		bestMoveCost := maxInt
-		bestMoveKey := uint64(0)
-		bestMoveJob := -1
-
-		// Запасной ход (лучший без учёта табу),
-		// используется если все допустимые ходы табуированы
-		fallbackFrom, fallbackTo := -1, -1
-		fallbackCost := maxInt
-		fallbackKey := uint64(0)
-		fallbackJob := -1
-
-		// Итерация по случайно сгенерированным соседям
-		for k := 0; k < neighbors; k++ {
-			from := s.Rng.Intn(n)
-			to := s.Rng.Intn(n - 1)
-			if to >= from {
-				to++
-			}
-
-			job := curr[from]
-			key := moveKey(job, from, to)
-
-			// Формирование соседнего решения
-			copy(cand, curr)
-			switch s.Cfg.Neighborhood {
-			case NeighborhoodInsert:
-				applyInsert(cand, from, to)
-			case NeighborhoodSwap:
-				applySwap(cand, from, to)
-			default:
-				applyInsert(cand, from, to)
-			}
-
-			cost := eval.MustMakespan(cand)
-			evals++
-
-			// Обновление хода
-			if cost < fallbackCost {
-				fallbackCost = cost
-				fallbackFrom, fallbackTo = from, to
-				fallbackKey = key
-				fallbackJob = job
-			}
-
-			isTabu := tabu.IsTabu(key, iter)
-			aspiration := cost < bestCost // критерий аспирации
-
-			// Табуированный ход пропускается,
-			// если не выполняется критерий аспирации
-			if isTabu && !aspiration {
-				continue
+		// Реакция на застой; за итерацию срабатывает не более одного механизма.
+		// После смены текущего решения табу-список очищается.
+		switch {
+		case stalled(iter, lastImprove, mem.lastJump, s.Cfg.BackJumpAfter):
+			// Возврат к последнему элитному решению;
+			// ход, сделанный из него ранее, запрещается
+			mem.lastJump = iter
+			if e, ok := elite.pop(); ok {
+				copy(curr, e.perm)
+				st.resync()
+				currCost = e.cost
+				st.tabu.reset()
+				for _, k := range e.first {
+					st.tabu.Add(k, iter+st.tenure())
+				}
+				backJumps++
 			}
-
-			if cost < bestMoveCost {
-				bestMoveCost = cost
-				bestMoveFrom, bestMoveTo = from, to
-				bestMoveKey = key
-				bestMoveJob = job
+		case stalled(iter, lastImprove, mem.lastIntensify, s.Cfg.IntensifyAfter):
+			// Интенсификация: перезапуск со случайного решения архива
+			mem.lastIntensify = iter
+			if e, ok := mem.pickElite(s.Rng); ok {
+				copy(curr, e.perm)
+				st.resync()
+				currCost = e.cost
+				st.tabu.reset()
+				mem.intensifications++
 			}
+		case stalled(iter, lastImprove, mem.lastRestart, s.Cfg.RestartAfter):
+			// Перезапуск со случайной перестановки
+			mem.lastRestart = iter
+			ls.ShufflePermutation(curr, s.Rng)
+			st.resync()
+			currCost = eval.MustMakespan(curr)
+			st.evals++
+			st.tabu.reset()
+			mem.restarts++
 		}
-
-		// Выбор хода: сначала допустимый лучший,
-		chosenFrom, chosenTo := bestMoveFrom, bestMoveTo
-		chosenCost := bestMoveCost
-		chosenKey := bestMoveKey
-		chosenJob := bestMoveJob
-
-		if chosenFrom < 0 {
-			chosenFrom, chosenTo = fallbackFrom, fallbackTo
-			chosenCost = fallbackCost
-			chosenKey = fallbackKey
-			chosenJob = fallbackJob
+		mem.updatePhase(iter, lastImprove)
+
+		// Выбор хода: сначала допустимый лучший, иначе лучший среди табуированных
+		var chosen ls.Move
+		var chosenCost int
+		switch s.Cfg.Search {
+		case SearchCritical:
+			chosen, chosenCost = st.criticalMove(iter)
+		case SearchFull:
+			chosen, chosenCost = st.fullMove(iter)
+		default:
+			chosen, chosenCost = st.sampledMove(iter)
 		}
 
 		// Нет допустимых ходов — завершаем поиск
-		if chosenFrom < 0 {
+		if chosenCost == maxInt {
 			break
 		}
+		if elite.pending {
+			elite.recordFirst(st.attributes(chosen))
+		}
 
 		// Применение выбранного хода
-		switch s.Cfg.Neighborhood {
-		case NeighborhoodInsert:
-			applyInsert(curr, chosenFrom, chosenTo)
-		case NeighborhoodSwap:
-			applySwap(curr, chosenFrom, chosenTo)
-		default:
-			applyInsert(curr, chosenFrom, chosenTo)
-		}
+		undo := st.applyMove(chosen)
 		currCost = chosenCost
 
-		// Добавление обратного хода в табу-список
-		tenure := s.Cfg.TabuTenure
+		// Добавление атрибутов отмены хода в табу-список
+		tenure := st.tenure()
 		if s.Cfg.TabuTenureRand > 0 {
 			tenure += s.Rng.Intn(s.Cfg.TabuTenureRand + 1)
 		}
-		reverseKey := moveKey(chosenJob, chosenTo, chosenFrom)
-		tabu.Add(reverseKey, iter+tenure)
-
-		_ = chosenKey
+		for _, k := range undo {
+			st.tabu.Add(k, iter+tenure)
+		}
+		if st.reactive != nil {
+			st.reactive.observe(st.hash, iter)
+		}
 
 		// Обновление глобально лучшего решения
-		if currCost < bestCost {
-			bestCost = currCost
+		if currCost < st.bestCost {
+			st.bestCost = currCost
 			copy(best, curr)
+			lastImprove = iter
+			elite.push(curr, currCost)
 		}
+		mem.record(curr, currCost)
+	}
+
+	meta := map[string]any{
+		"tabu_tenure":        s.Cfg.TabuTenure,
+		"tabu_tenure_rand":   s.Cfg.TabuTenureRand,
+		"neighbors_per_iter": s.Cfg.NeighborsPerIter,
+		"neighborhood":       string(s.Cfg.Neighborhood),
+		"search":             string(s.Cfg.Search),
+		"candidates":         string(s.Cfg.Candidates),
+		"window":             s.Cfg.Window,
+		"elite_size":         s.Cfg.EliteSize,
+		"back_jumps":         backJumps,
+		"attribute":          string(s.Cfg.Attribute),
+		"reactive":           s.Cfg.Reactive,
+	}
+	mem.meta(meta)
+	if st.reactive != nil {
+		st.reactive.meta(meta)
 	}
 
 	return opt.Result{
 		Permutation: best,
-		Makespan:    bestCost,
-		Evaluations: evals,
+		Makespan:    st.bestCost,
+		Evaluations: st.evals,
 		Iterations:  maxIter,
 		Duration:    time.Since(start),
-		Meta: map[string]any{
-			"tabu_tenure":        s.Cfg.TabuTenure,
-			"tabu_tenure_rand":   s.Cfg.TabuTenureRand,
-			"neighbors_per_iter": s.Cfg.NeighborsPerIter,
-			"neighborhood":       string(s.Cfg.Neighborhood),
-		},
+		Meta:        meta,
 	}, nil
 }
 
@@ -246,6 +242,14 @@ func newTabuList(capacity int) *tabuList {
 	}
 }
 
+// reset очищает табу-список.
+func (t *tabuList) reset() {
+	clear(t.m)
+	clear(t.key)
+	clear(t.exp)
+	t.i = 0
+}
+
 // IsTabu проверяет, является ли ход табуированным на текущей итерации.
 func (t *tabuList) IsTabu(k uint64, iter int) bool {
 	if exp, ok := t.m[k]; ok && exp > iter {
@@ -275,49 +279,6 @@ func (t *tabuList) Add(k uint64, expiry int) {
 	}
 }
 
-// initPermutation генерирует срез [0, 1, 2, ..., n-1].
-// Используется как базовое состояние перед случайной перестановкой.
-func initPermutation(p []int) {
-	for i := range p {
-		p[i] = i
-	}
-}
-
-// shufflePermutation выполняет случайную перестановку элементов.
-func shufflePermutation(p []int, rng *rand.Rand) {
-	for i := len(p) - 1; i > 0; i-- {
-		j := rng.Intn(i + 1)
-		p[i], p[j] = p[j], p[i]
-	}
-}
-
-// applySwap применяет swap-ход (обмен элементов в позициях i и j).
-func applySwap(p []int, i, j int) {
-	p[i], p[j] = p[j], p[i]
-}
-
-// applyInsert применяет insert-ход (элемент из позиции from вставляется в позицию to).
-func applyInsert(p []int, from, to int) {
-	if from == to {
-		return
-	}
-	val := p[from]
-	if from < to {
-		copy(p[from:to], p[from+1:to+1])
-		p[to] = val
-		return
-	}
-	copy(p[to+1:from+1], p[to:from])
-	p[to] = val
-}
-
-// moveKey формирует уникальный ключ хода
-func moveKey(job, from, to int) uint64 {
-	return (uint64(uint32(job)) << 42) |
-		(uint64(uint32(from)) << 21) |
-		uint64(uint32(to))
-}
-
 // max возвращает максимум из двух целых чисел.
 func max(a, b int) int {
 	if a > b {
@@ -0,0 +1,91 @@
+package vns
+
+import "fmt"
+
+// Neighborhood определяет тип окрестности.
+type Neighborhood string
+
+const (
+	NeighborhoodInsert   Neighborhood = "insert"
+	NeighborhoodSwap     Neighborhood = "swap"
+	NeighborhoodBlock    Neighborhood = "block"
+	NeighborhoodReversal Neighborhood = "reversal"
+)
+
+type Config struct {
+	// Iterations — общее число встрясок (shaking)
+	Iterations       int
+	IterationsPerJob int
+
+	// Neighborhoods — окрестности в порядке перебора N_1..N_k
+	Neighborhoods []Neighborhood
+
+	// ShakeMoves — число случайных ходов окрестности N_k при встряске
+	ShakeMoves int
+
+	// LocalSearchTries — число подряд неудачных случайных ходов,
+	// после которого спуск в окрестности N_k останавливается
+	LocalSearchTries int
+
+	// BlockMaxLen — максимальная длина переносимого блока (окрестность block)
+	BlockMaxLen int
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Iterations:       0,
+		IterationsPerJob: 60,
+
+		Neighborhoods: []Neighborhood{
+			NeighborhoodInsert,
+			NeighborhoodSwap,
+			NeighborhoodBlock,
+			NeighborhoodReversal,
+		},
+
+		ShakeMoves:       1,
+		LocalSearchTries: 100,
+		BlockMaxLen:      4,
+	}
+}
+
+func (c Config) Validate() error {
+	if c.Iterations <= 0 && c.IterationsPerJob <= 0 {
+		return fmt.Errorf(
+			"должно быть задано Iterations > 0 или IterationsPerJob > 0",
+		)
+	}
+	if len(c.Neighborhoods) == 0 {
+		return fmt.Errorf("список окрестностей пуст")
+	}
+	for _, nb := range c.Neighborhoods {
+		switch nb {
+		case NeighborhoodInsert, NeighborhoodSwap, NeighborhoodBlock, NeighborhoodReversal:
+			// ok
+		default:
+			return fmt.Errorf(
+				"неизвестный тип окрестности %q",
+				nb,
+			)
+		}
+	}
+	if c.ShakeMoves <= 0 {
+		return fmt.Errorf(
+			"ShakeMoves должно быть > 0 (получено %d)",
+			c.ShakeMoves,
+		)
+	}
+	if c.LocalSearchTries <= 0 {
+		return fmt.Errorf(
+			"LocalSearchTries должно быть > 0 (получено %d)",
+			c.LocalSearchTries,
+		)
+	}
+	if c.BlockMaxLen < 2 {
+		return fmt.Errorf(
+			"BlockMaxLen должно быть >= 2 (получено %d)",
+			c.BlockMaxLen,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package vns
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"flowShop/internal/flowshop"
+	"flowShop/internal/ls"
+	"flowShop/internal/opt"
+)
+
+// Solver — реализация метода поиска с чередующимися окрестностями (VNS).
+type Solver struct {
+	Cfg Config
+	Rng *rand.Rand
+}
+
+// New возвращает новый VNS-солвер с валидацией конфигурации, с использованием инициализированного генератора случайных чисел.
+// Используется в фабриках.
+func New(cfg Config, rng *rand.Rand) (*Solver, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if rng == nil {
+		return nil, fmt.Errorf("генератор случайных чисел не инициализирован (nil)")
+	}
+	return &Solver{Cfg: cfg, Rng: rng}, nil
+}
+
+// Solve — основной цикл базового VNS: встряска в N_k, спуск в N_k;
+// при улучшении возврат к N_1, иначе переход к N_{k+1}.
+func (s *Solver) Solve(ctx context.Context, inst *flowshop.Instance) (opt.Result, error) {
+	start := time.Now()
+
+	if err := inst.Validate(); err != nil {
+		return opt.Result{}, err
+	}
+	if err := s.Cfg.Validate(); err != nil {
+		return opt.Result{}, err
+	}
+	if s.Rng == nil {
+		return opt.Result{}, fmt.Errorf("генератор случайных чисел не инициализирован (nil)")
+	}
+
+	eval, err := flowshop.NewEvaluator(inst)
+	if err != nil {
+		return opt.Result{}, err
+	}
+
+	n := inst.Jobs
+
+	maxIter := s.Cfg.Iterations
+	if maxIter <= 0 {
+		maxIter = s.Cfg.IterationsPerJob * n
+	}
+
+	// Текущее решение, результат встряски/спуска и пробный ход
+	curr := make([]int, n)
+	shaken := make([]int, n)
+	cand := make([]int, n)
+
+	ls.InitPermutation(curr)
+	ls.ShufflePermutation(curr, s.Rng)
+	currCost := eval.MustMakespan(curr)
+	evals := 1
+
+	neighborhoods := s.Cfg.Neighborhoods
+	improvements := make([]int, len(neighborhoods))
+	k := 0
+
+	for iter := 0; iter < maxIter; iter++ {
+		// Для поддержки отмены через context
+		if err := ctx.Err(); err != nil {
+			return opt.Result{
+				Permutation: curr,
+				Makespan:    currCost,
+				Evaluations: evals,
+				Iterations:  iter,
+				Duration:    time.Since(start),
+				Meta: map[string]any{
+					"stopped": "context",
+				},
+			}, err
+		}
+
+		nb := neighborhoods[k]
+
+		// Встряска
+		copy(shaken, curr)
+		for i := 0; i < s.Cfg.ShakeMoves; i++ {
+			s.randomMove(shaken, nb)
+		}
+		shakenCost := eval.MustMakespan(shaken)
+		evals++
+
+		// Спуск с первым улучшением в той же окрестности
+		fails := 0
+		for fails < s.Cfg.LocalSearchTries {
+			copy(cand, shaken)
+			s.randomMove(cand, nb)
+			cost := eval.MustMakespan(cand)
+			evals++
+			if cost < shakenCost {
+				shaken, cand = cand, shaken
+				shakenCost = cost
+				fails = 0
+			} else {
+				fails++
+			}
+		}
+
+		// Смена окрестности
+		if shakenCost < currCost {
+			curr, shaken = shaken, curr
+			currCost = shakenCost
+			improvements[k]++
+			k = 0
+		} else {
+			k = (k + 1) % len(neighborhoods)
+		}
+	}
+
+	names := make([]string, len(neighborhoods))
+	for i, nb := range neighborhoods {
+		names[i] = string(nb)
+	}
+	return opt.Result{
+		Permutation: curr,
+		Makespan:    currCost,
+		Evaluations: evals,
+		Iterations:  maxIter,
+		Duration:    time.Since(start),
+		Meta: map[string]any{
+			"neighborhoods":      names,
+			"improvements":       improvements,
+			"shake_moves":        s.Cfg.ShakeMoves,
+			"local_search_tries": s.Cfg.LocalSearchTries,
+		},
+	}, nil
+}
+
+// randomMove применяет к p случайный ход окрестности nb.
+func (s *Solver) randomMove(p []int, nb Neighborhood) {
+	n := len(p)
+	if n < 2 {
+		return
+	}
+	switch nb {
+	case NeighborhoodSwap:
+		i, j := distinctPair(n, s.Rng)
+		p[i], p[j] = p[j], p[i]
+	case NeighborhoodBlock:
+		length := 2 + s.Rng.Intn(max(1, min(s.Cfg.BlockMaxLen, n-1)-1))
+		length = min(length, n-1)
+		from := s.Rng.Intn(n - length + 1)
+		to := s.Rng.Intn(n - length)
+		if to >= from {
+			to++
+		}
+		ls.ApplyBlockMove(p, from, length, to)
+	case NeighborhoodReversal:
+		i, j := distinctPair(n, s.Rng)
+		if i > j {
+			i, j = j, i
+		}
+		for ; i < j; i, j = i+1, j-1 {
+			p[i], p[j] = p[j], p[i]
+		}
+	default:
+		from, to := distinctPair(n, s.Rng)
+		ls.ApplyInsert(p, from, to)
+	}
+}
+
+// distinctPair возвращает две различные случайные позиции.
+func distinctPair(n int, rng *rand.Rand) (int, int) {
+	i := rng.Intn(n)
+	j := rng.Intn(n - 1)
+	if j >= i {
+		j++
+	}
+	return i, j
+}